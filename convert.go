@@ -0,0 +1,91 @@
+package main
+
+import (
+    "context"
+    "fmt"
+    "github.com/eyjian/mooon-district/district"
+    "io"
+    "os"
+)
+
+// runConvert 将数据源文件转换为一种格式，缺省输出到标准输出，
+// 如将统计用区划代码格式的数据源文件转换为省市县三级的数据源文件：-to=source
+// 用法：mooon-district convert -f 2023.csv.gz -to=json [-o district.json]
+func runConvert(args []string) int {
+    flagSet := newFlagSet("convert", "convert [-f district-2023.csv] -to=source|json|csv|sql|xlsx [-o output]")
+    data := newDataFlags(flagSet)
+    to := flagSet.String("to", "", "Target format: source (the district data file format), json, csv, sql or xlsx.")
+    output := flagSet.String("o", stdout, "Output file, '-' means stdout (not for xlsx).")
+    force := flagSet.Bool("force", false, "Whether to overwrite the existing output file.")
+    sqlTable := flagSet.String("sql-table", "t_dict_district", "Table name for sql data.")
+    _ = flagSet.Parse(args)
+
+    if *to != "source" && *to != "json" && *to != "csv" && *to != "sql" && *to != "xlsx" {
+        fmt.Fprintf(os.Stderr, "Parameter -to is invalid: %s.\n", *to)
+        flagSet.Usage()
+        return 1
+    }
+    if *to == "xlsx" && *output == stdout {
+        fmt.Fprintf(os.Stderr, "Xlsx can not be written to stdout, use -o to set the output file.\n")
+        return 1
+    }
+    if *to == "sql" && len(*sqlTable) == 0 {
+        fmt.Fprintf(os.Stderr, "Parameter -sql-table is not set.\n")
+        return 1
+    }
+
+    districtTable, err := data.load(context.Background())
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "%s.\n", capitalize(err.Error()))
+        return 2
+    }
+
+    if *output != stdout {
+        if _, err := os.Stat(*output); err == nil && !*force {
+            fmt.Fprintf(os.Stderr, "File://%s already exists, use -force to overwrite.\n", *output)
+            return 3
+        }
+    }
+    switch *to {
+    case "xlsx":
+        err = district.GenerateXlsx(districtTable, *output)
+    default:
+        err = convertTo(districtTable, *to, *output, *sqlTable)
+    }
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Convert to %s error: %s.\n", *to, err.Error())
+        return 3
+    }
+    return 0
+}
+
+// convertTo 将行政区数据转换为文本格式写到输出文件，关闭文件出错（如写盘失败）也返回错误
+func convertTo(districtTable *district.Table, to, output, sqlTable string) error {
+    if output == stdout {
+        return writeTo(districtTable, to, os.Stdout, sqlTable)
+    }
+
+    file, err := os.Create(output)
+    if err != nil {
+        return err
+    }
+    err = writeTo(districtTable, to, file, sqlTable)
+    if closeErr := file.Close(); err == nil {
+        err = closeErr
+    }
+    return err
+}
+
+// writeTo 将行政区数据转换为文本格式写到 w
+func writeTo(districtTable *district.Table, to string, w io.Writer, sqlTable string) error {
+    switch to {
+    case "json":
+        return district.WriteJson(districtTable, w, true, "  ", "")
+    case "csv":
+        return district.WriteCsv(districtTable, w, district.CsvOptions{Delimiter: ",", WithCode: true})
+    case "sql":
+        return district.WriteSql(districtTable, w, district.SqlOptions{TableName: sqlTable})
+    default:
+        return district.WriteDistrict(districtTable, w)
+    }
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "github.com/eyjian/mooon-district/district"
+    "github.com/eyjian/mooon-district/district/dataset"
+    "os"
+)
+
+// runDiff 比较新旧两份数据源文件，输出变更集或增量升级的 SQL
+// 用法：mooon-district diff -old district-2022.csv -new district-2023.csv [-format=markdown]
+func runDiff(args []string) int {
+    flagSet := newFlagSet("diff", "diff -old district-2022.csv -new district-2023.csv [-format=markdown]")
+    oldFile := flagSet.String("old", "", "Path to the old district data file.")
+    newFile := flagSet.String("new", "", "Path to the new district data file.")
+    format := flagSet.String("format", "text", "Output format of the changes: text, json, markdown or sql.")
+    sqlTable := flagSet.String("sql-table", "t_dict_district", "Table name for sql migration when -format=sql.")
+    withPinyin := flagSet.Bool("with-pinyin", false, "Whether the table of sql migration has the pinyin columns, the same as generate -with-pinyin.")
+    withPostal := flagSet.Bool("with-postal", false, "Whether the table of sql migration has the postal columns, the same as generate -with-postal.")
+    postalCodes := flagSet.String("postal-codes", "", "Path to the area codes and postal codes file when -with-postal, format: DistrictCode,AreaCode,PostalCode.")
+    _ = flagSet.Parse(args)
+
+    if len(*oldFile) == 0 || len(*newFile) == 0 {
+        fmt.Fprintf(os.Stderr, "Parameter -old or -new is not set.\n")
+        return 1
+    }
+    if *format != "text" && *format != "json" && *format != "markdown" && *format != "sql" {
+        fmt.Fprintf(os.Stderr, "Parameter -format is invalid: %s.\n", *format)
+        return 1
+    }
+
+    ctx := context.Background()
+    oldTable, err := district.LoadDistrict(ctx, *oldFile)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Load district error: %s.\n", err.Error())
+        return 2
+    }
+    newTable, err := district.LoadDistrict(ctx, *newFile)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Load district error: %s.\n", err.Error())
+        return 2
+    }
+
+    if *format == "sql" {
+        if len(*sqlTable) == 0 {
+            fmt.Fprintf(os.Stderr, "Parameter -sql-table is not set.\n")
+            return 1
+        }
+        // 和 generate 一样使用内嵌的官方英文名和 -postal-codes 指定的电话区号和邮政编码
+        if *withPinyin {
+            names, err := dataset.EnglishNames()
+            if err != nil {
+                fmt.Fprintf(os.Stderr, "Load english names error: %s.\n", err.Error())
+                return 2
+            }
+            oldTable.SetEnglishNames(names)
+            newTable.SetEnglishNames(names)
+        }
+        if *withPostal && len(*postalCodes) > 0 {
+            postalInfos, err := district.LoadPostalInfo(ctx, *postalCodes)
+            if err != nil {
+                fmt.Fprintf(os.Stderr, "Load postal codes error: %s.\n", err.Error())
+                return 2
+            }
+            oldTable.SetPostalInfo(postalInfos)
+            newTable.SetPostalInfo(postalInfos)
+        }
+        fmt.Print(district.MigrationSqlWithOptions(oldTable, newTable, district.SqlOptions{
+            TableName:  *sqlTable,
+            WithPinyin: *withPinyin,
+            WithPostal: *withPostal,
+        }))
+        return 0
+    }
+
+    changeSet := district.Diff(oldTable, newTable)
+    switch *format {
+    case "json":
+        jsonBytes, err := json.MarshalIndent(changeSet, "", "  ")
+        if err != nil {
+            fmt.Fprintf(os.Stderr, "Json marshal error: %s.\n", err.Error())
+            return 2
+        }
+        fmt.Println(string(jsonBytes))
+    case "markdown":
+        fmt.Print(changeSet.Markdown())
+    default:
+        fmt.Print(changeSet.String())
+    }
+    return 0
+}
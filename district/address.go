@@ -0,0 +1,192 @@
+// Package district
+package district
+
+import (
+	"strings"
+)
+
+const (
+	addressScoreFullName  = 1.0 // 全名匹配，如：广东省
+	addressScoreShortName = 0.8 // 简名匹配，如：广东
+	addressScoreInferred  = 0.6 // 地址中缺失，由下级行政区推断得出
+)
+
+// Address 从地址中解析出的行政区
+type Address struct {
+	Code       Code    `json:"code"`
+	Name       Name    `json:"name"`
+	Street     string  `json:"street"`     // 行政区之后剩余的部分，如：情侣路1号
+	Confidence float64 `json:"confidence"` // 置信度，取值 0 到 1 ，为 0 表示未识别出任何行政区
+}
+
+// AddressParser 地址解析器，从自由文本的地址中识别省、市、县三级行政区
+type AddressParser struct {
+	provinces []*addressNode
+	cities    []*addressNode // 所有市级行政区，地址中缺失省级行政区时使用
+	counties  []*addressNode // 所有县级行政区，地址中缺失省级和市级行政区时使用
+}
+
+type addressNode struct {
+	code     uint32
+	name     string
+	aliases  []string // 第一个为全名，其后为简名
+	parent   *addressNode
+	children []*addressNode
+}
+
+type addressMatch struct {
+	node   *addressNode
+	length int // 匹配的字节数
+	score  float64
+}
+
+// NewAddressParser 新建地址解析器
+func NewAddressParser(table *Table) *AddressParser {
+	p := &AddressParser{
+		provinces: make([]*addressNode, 0),
+		cities:    make([]*addressNode, 0),
+		counties:  make([]*addressNode, 0),
+	}
+
+	for _, provinceDistrict := range table.Provinces {
+		province := newAddressNode(provinceDistrict.Code, provinceDistrict.Name, nil)
+		p.provinces = append(p.provinces, province)
+
+		for _, cityDistrict := range provinceDistrict.Cities {
+			city := newAddressNode(cityDistrict.Code, cityDistrict.Name, province)
+			p.cities = append(p.cities, city)
+
+			for _, countyDistrict := range cityDistrict.Counties {
+				county := newAddressNode(countyDistrict.Code, countyDistrict.Name, city)
+				p.counties = append(p.counties, county)
+			}
+		}
+	}
+
+	return p
+}
+
+func newAddressNode(code uint32, name string, parent *addressNode) *addressNode {
+	node := &addressNode{
+		code:    code,
+		name:    name,
+		aliases: []string{name},
+		parent:  parent,
+	}
+	if shortName := shortDistrictName(name); shortName != name {
+		node.aliases = append(node.aliases, shortName)
+	}
+	if parent != nil {
+		parent.children = append(parent.children, node)
+	}
+	return node
+}
+
+// Parse 解析地址，可容忍缺失的级别、不带省/市/区等后缀的简名，以及省略了市级的直辖市地址，
+// 如：“广东珠海香洲区情侣路1号”、“北京海淀中关村”和“珠海香洲情侣路”
+func (p *AddressParser) Parse(address string) *Address {
+	text := strings.TrimSpace(address)
+	var province, city, county *addressMatch
+
+	// 省级行政区
+	if province = matchAddress(p.provinces, text); province != nil {
+		text = text[province.length:]
+
+		// 直辖市重复的市名，如：上海市上海市浦东新区
+		if IsMunicipalityCode(province.node.code) {
+			if m := matchAddress([]*addressNode{province.node}, text); m != nil {
+				text = text[m.length:]
+			}
+		}
+	}
+
+	// 市级行政区
+	cities := p.cities
+	if province != nil {
+		cities = province.node.children
+	}
+	if city = matchAddress(cities, text); city != nil {
+		text = text[city.length:]
+	}
+
+	// 县级行政区，缺失市级行政区时在省内或全部县级行政区中找
+	var counties []*addressNode
+	if city != nil {
+		counties = city.node.children
+	} else if province != nil {
+		counties = make([]*addressNode, 0)
+		for _, c := range province.node.children {
+			counties = append(counties, c.children...)
+		}
+	} else {
+		counties = p.counties
+	}
+	if county = matchAddress(counties, text); county != nil {
+		text = text[county.length:]
+	}
+
+	// 由下级行政区推断缺失的上级行政区
+	if county != nil && city == nil {
+		city = &addressMatch{node: county.node.parent, score: addressScoreInferred}
+	}
+	if city != nil && province == nil {
+		province = &addressMatch{node: city.node.parent, score: addressScoreInferred}
+	}
+
+	result := &Address{Street: strings.TrimSpace(text)}
+	levels := 0
+	score := 0.0
+	if province != nil {
+		result.Code.ProvinceCode = province.node.code
+		result.Name.ProvinceName = province.node.name
+		levels++
+		score += province.score
+	}
+	if city != nil {
+		result.Code.CityCode = city.node.code
+		result.Name.CityName = city.node.name
+		levels++
+		score += city.score
+	}
+	if county != nil {
+		result.Code.CountyCode = county.node.code
+		result.Name.CountyName = county.node.name
+		levels++
+		score += county.score
+	}
+	if levels > 0 {
+		result.Confidence = score / float64(levels)
+	}
+	return result
+}
+
+// matchAddress 在 nodes 中找名称为 text 前缀的行政区，取匹配最长的，
+// 最长的匹配有多个不同的行政区时视为有歧义，返回 nil
+func matchAddress(nodes []*addressNode, text string) *addressMatch {
+	var best *addressMatch
+	ambiguous := false
+
+	for _, node := range nodes {
+		for i, alias := range node.aliases {
+			if !strings.HasPrefix(text, alias) {
+				continue
+			}
+
+			score := addressScoreFullName
+			if i > 0 {
+				score = addressScoreShortName
+			}
+			if best == nil || len(alias) > best.length {
+				best = &addressMatch{node: node, length: len(alias), score: score}
+				ambiguous = false
+			} else if len(alias) == best.length && node != best.node {
+				ambiguous = true
+			}
+		}
+	}
+
+	if ambiguous {
+		return nil
+	}
+	return best
+}
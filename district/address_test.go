@@ -0,0 +1,40 @@
+// Package district
+package district
+
+import (
+	"context"
+	"testing"
+)
+
+// go test -v -run="TestAddressParser$"
+func TestAddressParser(t *testing.T) {
+	table, err := LoadDistrict(context.Background(), "../district-2023.csv")
+	if err != nil {
+		t.Fatalf("LoadDistrict error: %s\n", err.Error())
+	}
+	parser := NewAddressParser(table)
+
+	cases := []struct {
+		address    string
+		code       Code
+		street     string
+		confidence float64
+	}{
+		{"广东省珠海市香洲区情侣路1号", Code{440000, 440400, 440402}, "情侣路1号", 1.0},
+		{"广东珠海香洲区情侣路1号", Code{440000, 440400, 440402}, "情侣路1号", 0.86},
+		{"北京海淀中关村", Code{110000, 110108, 0}, "中关村", 0.8},
+		{"上海市上海市浦东新区世纪大道", Code{310000, 310115, 0}, "世纪大道", 1.0},
+		{"珠海香洲情侣路", Code{440000, 440400, 440402}, "情侣路", 0.73},
+		{"广东香洲区情侣路", Code{440000, 440400, 440402}, "情侣路", 0.8},
+		{"河南省济源市", Code{410000, 419001, 0}, "", 1.0},
+		{"火星基地", Code{}, "火星基地", 0},
+	}
+	for _, c := range cases {
+		address := parser.Parse(c.address)
+		if address.Code != c.code || address.Street != c.street || address.Confidence < c.confidence-0.01 || address.Confidence > c.confidence+0.01 {
+			t.Errorf("Parse(%s): %+v\n", c.address, *address)
+		} else {
+			t.Logf("Parse(%s): %+v\n", c.address, *address)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Package district
+package district
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// CascaderOptions 级联选择器（如 Element 和 Ant Design 的 Cascader）数据的选项
+type CascaderOptions struct {
+	ValueKey     string // 值的键名，缺省为 value ，也可为 code 等
+	LabelKey     string // 显示名的键名，缺省为 label ，也可为 name 等
+	ChildrenKey  string // 下一级的键名，缺省为 children ，也可为 areaList 等
+	CodeAsString bool   // 值是否为字符串，如："440402"，缺省为数值
+	Levels       int    // 级数，2 时只到市级，1 时只有省级，缺省为 3
+}
+
+// CascaderNode 级联选择器的一个节点，按 CascaderOptions 的键名输出 json ，最低一级没有下一级的键
+type CascaderNode struct {
+	Code     uint32
+	Name     string
+	Children []*CascaderNode
+
+	options *CascaderOptions
+}
+
+// Cascader 将行政区数据转换为级联选择器的树，直辖市的区县和省直辖县级市同 Table 一样在市级
+func Cascader(districtTable *Table, options CascaderOptions) []*CascaderNode {
+	if len(options.ValueKey) == 0 {
+		options.ValueKey = "value"
+	}
+	if len(options.LabelKey) == 0 {
+		options.LabelKey = "label"
+	}
+	if len(options.ChildrenKey) == 0 {
+		options.ChildrenKey = "children"
+	}
+	if options.Levels <= 0 || options.Levels > 3 {
+		options.Levels = 3
+	}
+
+	nodes := make([]*CascaderNode, 0, len(districtTable.Provinces))
+	for _, provinceDistrict := range districtTable.Provinces {
+		provinceNode := &CascaderNode{Code: provinceDistrict.Code, Name: provinceDistrict.Name, options: &options}
+		nodes = append(nodes, provinceNode)
+		if options.Levels < 2 {
+			continue
+		}
+
+		for _, cityDistrict := range provinceDistrict.Cities {
+			cityNode := &CascaderNode{Code: cityDistrict.Code, Name: cityDistrict.Name, options: &options}
+			provinceNode.Children = append(provinceNode.Children, cityNode)
+			if options.Levels < 3 {
+				continue
+			}
+
+			for _, countyDistrict := range cityDistrict.Counties {
+				cityNode.Children = append(cityNode.Children,
+					&CascaderNode{Code: countyDistrict.Code, Name: countyDistrict.Name, options: &options})
+			}
+		}
+	}
+	return nodes
+}
+
+// MarshalJSON 按 CascaderOptions 的键名输出，键的顺序为值、显示名和下一级
+func (n *CascaderNode) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	var value any = n.Code
+	if n.options.CodeAsString {
+		value = strconv.FormatUint(uint64(n.Code), 10)
+	}
+
+	buf.WriteString("{")
+	for i, field := range []struct {
+		key   string
+		value any
+	}{
+		{n.options.ValueKey, value}, {n.options.LabelKey, n.Name}, {n.options.ChildrenKey, n.Children},
+	} {
+		if i == 2 && len(n.Children) == 0 {
+			break
+		}
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		key, _ := json.Marshal(field.key)
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+func GenerateCascaderJson(districtTable *Table, jsonFilepath string, options CascaderOptions, withIndent bool, indent, prefix string) error {
+	return generateFile(jsonFilepath, func(w io.Writer) error {
+		return WriteCascaderJson(districtTable, w, options, withIndent, indent, prefix)
+	})
+}
+
+// WriteCascaderJson 将级联选择器的 json 数据写到 w ，如：[{"value":440000,"label":"广东省","children":[...]}]
+func WriteCascaderJson(districtTable *Table, w io.Writer, options CascaderOptions, withIndent bool, indent, prefix string) error {
+	return writeJsonValue(w, Cascader(districtTable, options), withIndent, indent, prefix)
+}
+
+// VantArea Vant 的 Area 省市区选择组件的数据，键为字符串的行政区代码
+type VantArea struct {
+	ProvinceList map[string]string `json:"province_list"`
+	CityList     map[string]string `json:"city_list"`
+	CountyList   map[string]string `json:"county_list"`
+}
+
+// NewVantArea 将行政区数据转换为 Vant 的 Area 组件的数据，Vant 要求三级：
+// 直辖市的区县在 county_list ，市级为 xx0100（如：110100 北京市），县为 xx0200 县（如：500200）；
+// 省直辖县级市在 county_list ，市级为 xx9000 省直辖县级行政区划（如：419000）；
+// 没有区县的市（如：441900 东莞市）在 county_list 中补上同代码同名的一项，使第三级可选
+func NewVantArea(districtTable *Table) *VantArea {
+	area := &VantArea{
+		ProvinceList: make(map[string]string),
+		CityList:     make(map[string]string),
+		CountyList:   make(map[string]string),
+	}
+
+	for _, provinceDistrict := range districtTable.Provinces {
+		area.ProvinceList[vantCode(provinceDistrict.Code)] = provinceDistrict.Name
+
+		for _, cityDistrict := range provinceDistrict.Cities {
+			if !IsCountyDistrictCode(cityDistrict.Code) {
+				area.CityList[vantCode(cityDistrict.Code)] = cityDistrict.Name
+				for _, countyDistrict := range cityDistrict.Counties {
+					area.CountyList[vantCode(countyDistrict.Code)] = countyDistrict.Name
+				}
+				if len(cityDistrict.Counties) == 0 {
+					area.CountyList[vantCode(cityDistrict.Code)] = cityDistrict.Name
+				}
+				continue
+			}
+
+			// 直辖市的区县和省直辖县级市，补上市级
+			cityCode := getCityDistrictCode(cityDistrict.Code)
+			if provinceDistrict.Municipality && cityCode%10000 == 200 {
+				// 直辖市的县，如：500229 城口县
+				area.CityList[vantCode(cityCode)] = "县"
+			} else if provinceDistrict.Municipality {
+				area.CityList[vantCode(cityCode)] = provinceDistrict.Name
+			} else {
+				area.CityList[vantCode(cityCode)] = "省直辖县级行政区划"
+			}
+			area.CountyList[vantCode(cityDistrict.Code)] = cityDistrict.Name
+		}
+	}
+	return area
+}
+
+func GenerateVantJson(districtTable *Table, jsonFilepath string, withIndent bool, indent, prefix string) error {
+	return generateFile(jsonFilepath, func(w io.Writer) error {
+		return WriteVantJson(districtTable, w, withIndent, indent, prefix)
+	})
+}
+
+// WriteVantJson 将 Vant 的 Area 组件的 json 数据写到 w ，
+// 如：{"province_list":{"440000":"广东省"},"city_list":{...},"county_list":{...}}
+func WriteVantJson(districtTable *Table, w io.Writer, withIndent bool, indent, prefix string) error {
+	return writeJsonValue(w, NewVantArea(districtTable), withIndent, indent, prefix)
+}
+
+func vantCode(code uint32) string {
+	return fmt.Sprintf("%06d", code)
+}
+
+// writeJsonValue 将 v 的 json 写到 w
+func writeJsonValue(w io.Writer, v any, withIndent bool, indent, prefix string) error {
+	var err error
+	var jsonBytes []byte
+
+	if !withIndent {
+		jsonBytes, err = json.Marshal(v)
+	} else {
+		jsonBytes, err = json.MarshalIndent(v, prefix, indent)
+	}
+	if err != nil {
+		return fmt.Errorf("json marshal error: %s", err.Error())
+	}
+
+	_, err = w.Write(jsonBytes)
+	return err
+}
@@ -0,0 +1,95 @@
+// Package district
+package district
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// go test -v -run="TestWriteCascaderJson$"
+func TestWriteCascaderJson(t *testing.T) {
+	table, err := LoadDistrictFromReader(context.Background(), strings.NewReader(testDistrictData))
+	if err != nil {
+		t.Fatalf("LoadDistrictFromReader error: %s\n", err.Error())
+	}
+
+	var buf bytes.Buffer
+	if err = WriteCascaderJson(table, &buf, CascaderOptions{}, false, "", ""); err != nil {
+		t.Fatalf("WriteCascaderJson error: %s\n", err.Error())
+	}
+	expect := `[{"value":110000,"label":"北京市","children":[{"value":110108,"label":"海淀区"}]},` +
+		`{"value":440000,"label":"广东省","children":[{"value":440400,"label":"珠海市","children":[{"value":440402,"label":"香洲区"},{"value":440403,"label":"斗门区"}]},{"value":441900,"label":"东莞市"}]}]`
+	if buf.String() != expect {
+		t.Errorf("WriteCascaderJson: %s\n", buf.String())
+	}
+
+	buf.Reset()
+	options := CascaderOptions{ValueKey: "code", LabelKey: "name", ChildrenKey: "areaList", CodeAsString: true, Levels: 2}
+	if err = WriteCascaderJson(table, &buf, options, false, "", ""); err != nil {
+		t.Fatalf("WriteCascaderJson error: %s\n", err.Error())
+	}
+	if !strings.Contains(buf.String(), `{"code":"440400","name":"珠海市"}`) || strings.Contains(buf.String(), "香洲区") {
+		t.Errorf("WriteCascaderJson(options): %s\n", buf.String())
+	}
+}
+
+// go test -v -run="TestWriteVantJson$"
+func TestWriteVantJson(t *testing.T) {
+	table, err := LoadDistrict(context.Background(), "../district-2023.csv")
+	if err != nil {
+		t.Fatalf("LoadDistrict error: %s\n", err.Error())
+	}
+
+	var buf bytes.Buffer
+	if err = WriteVantJson(table, &buf, false, "", ""); err != nil {
+		t.Fatalf("WriteVantJson error: %s\n", err.Error())
+	}
+	var area VantArea
+	if err = json.Unmarshal(buf.Bytes(), &area); err != nil {
+		t.Fatalf("json.Unmarshal error: %s\n", err.Error())
+	}
+
+	expects := []struct {
+		list map[string]string
+		code string
+		name string
+	}{
+		{area.ProvinceList, "440000", "广东省"},
+		{area.CityList, "440400", "珠海市"},
+		{area.CountyList, "440402", "香洲区"},
+		{area.CityList, "110100", "北京市"},
+		{area.CountyList, "110108", "海淀区"},
+		{area.CityList, "419000", "省直辖县级行政区划"},
+		{area.CountyList, "419001", "济源市"},
+		{area.CityList, "500100", "重庆市"},
+		{area.CityList, "500200", "县"},
+		{area.CountyList, "500229", "城口县"},
+	}
+	for _, expect := range expects {
+		if name := expect.list[expect.code]; name != expect.name {
+			t.Errorf("%s: %s, expect %s\n", expect.code, name, expect.name)
+		}
+	}
+
+	// 每个市都有区县，否则 Vant 的第三列为空，不能完成选择
+	for cityCode, cityName := range area.CityList {
+		found := false
+		for countyCode := range area.CountyList {
+			if countyCode[:4] == cityCode[:4] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("%s %s: no county\n", cityCode, cityName)
+		}
+	}
+	for _, code := range []string{"441900", "442000", "620200", "460300", "460400", "820200", "820300"} {
+		if len(area.CountyList[code]) == 0 || area.CountyList[code] != area.CityList[code] {
+			t.Errorf("%s: county %s, city %s\n", code, area.CountyList[code], area.CityList[code])
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Package dataset 内嵌民政部发布的各年度行政区数据，
+// 引入本包即可得到自包含的行政区字典，无需另行提供数据文件
+package dataset
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"github.com/eyjian/mooon-district/district"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// location 生效日期的时区，固定为北京时间（UTC+8），使 Store 的结果不依赖主机的时区
+var location = time.FixedZone("CST", 8*60*60)
+
+// 数据源文件从仓库根目录同步：在本目录执行 go generate ，TestSameAsRoot 检查两者是否一致
+//go:generate sh -c "cp ../../district-*.csv ."
+
+//go:embed district-*.csv succession.csv english.csv
+var files embed.FS
+
+// Years 取得内嵌数据的所有年度，按从小到大排序
+func Years() []int {
+	years := make([]int, 0)
+	entries, _ := files.ReadDir(".")
+	for _, entry := range entries {
+		var year int
+		if _, err := fmt.Sscanf(entry.Name(), "district-%d.csv", &year); err == nil {
+			years = append(years, year)
+		}
+	}
+	sort.Ints(years)
+	return years
+}
+
+// LatestYear 取得内嵌数据的最新年度
+func LatestYear() int {
+	years := Years()
+	return years[len(years)-1]
+}
+
+// Default 取得最新年度的行政区数据
+func Default() (*district.Table, error) {
+	return ByYear(LatestYear())
+}
+
+// ByYear 取得指定年度的行政区数据，每次调用都返回新的 Table ，调用方可自由修改，
+// 内嵌的官方英文名已覆盖缺省的罗马字拼写
+func ByYear(year int) (*district.Table, error) {
+	data, err := Bytes(year)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := district.LoadDistrictFromReader(context.Background(), bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("load district of %d error: %s", year, err.Error())
+	}
+
+	englishNames, err := EnglishNames()
+	if err != nil {
+		return nil, err
+	}
+	table.SetEnglishNames(englishNames)
+	return table, nil
+}
+
+// Bytes 取得指定年度的原始数据文件内容
+func Bytes(year int) ([]byte, error) {
+	data, err := files.ReadFile(fmt.Sprintf("district-%d.csv", year))
+	if err != nil {
+		return nil, fmt.Errorf("no embedded district data of %d", year)
+	}
+	return data, nil
+}
+
+// Store 取得包含所有内嵌年度的多版本行政区数据，以各年度北京时间的 1 月 1 日零时作为生效日期，
+// 如 2022-12-31T16:00:00Z 起为 2023 年度的数据
+func Store() (*district.VersionedStore, error) {
+	store := district.NewVersionedStore()
+	for _, year := range Years() {
+		table, err := ByYear(year)
+		if err != nil {
+			return nil, err
+		}
+		store.Add(strconv.Itoa(year), time.Date(year, 1, 1, 0, 0, 0, 0, location), table)
+	}
+	return store, nil
+}
+
+// Succession 取得内嵌的行政区代码继承关系（数据同 CHANGES.md）
+func Succession() (*district.SuccessionRegistry, error) {
+	data, err := files.ReadFile("succession.csv")
+	if err != nil {
+		return nil, err
+	}
+
+	registry, err := district.LoadSuccessionFromReader(context.Background(), bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("load succession error: %s", err.Error())
+	}
+	return registry, nil
+}
+
+// EnglishNames 取得内嵌的行政区官方英文名（如：内蒙古自治区 => Inner Mongolia），键为 6 位行政区代码
+func EnglishNames() (map[uint32]string, error) {
+	data, err := files.ReadFile("english.csv")
+	if err != nil {
+		return nil, err
+	}
+
+	englishNames, err := district.LoadEnglishNamesFromReader(context.Background(), bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("load english names error: %s", err.Error())
+	}
+	return englishNames, nil
+}
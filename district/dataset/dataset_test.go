@@ -0,0 +1,119 @@
+// Package dataset
+package dataset
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// go test -v -run="TestByYear$"
+func TestByYear(t *testing.T) {
+	years := Years()
+	if len(years) < 2 || years[0] != 2022 || years[1] != 2023 {
+		t.Fatalf("Years: %v\n", years)
+	}
+
+	for _, year := range years {
+		table, err := ByYear(year)
+		if err != nil {
+			t.Errorf("ByYear(%d) error: %s\n", year, err.Error())
+		} else if len(table.Provinces) != 34 {
+			t.Errorf("ByYear(%d): %d provinces\n", year, len(table.Provinces))
+		}
+	}
+
+	if _, err := ByYear(2000); err == nil {
+		t.Errorf("ByYear(2000): expect error\n")
+	}
+
+	table, err := Default()
+	if err != nil {
+		t.Fatalf("Default error: %s\n", err.Error())
+	}
+	if _, ok := table.ProvinceDistrictTable[650000].CityDistrictTable[659012]; !ok {
+		t.Errorf("Default: 659012 not found\n")
+	}
+	if englishName := table.ProvinceDistrictTable[150000].EnglishName; englishName != "Inner Mongolia" {
+		t.Errorf("Default: english name of 150000 is %s\n", englishName)
+	}
+	if englishName := table.Provinces[0].EnglishName; englishName != "Beijing Shi" {
+		t.Errorf("Default: english name of %d is %s\n", table.Provinces[0].Code, englishName)
+	}
+}
+
+// go test -v -run="TestSuccession$"
+func TestSuccession(t *testing.T) {
+	registry, err := Succession()
+	if err != nil {
+		t.Fatalf("Succession error: %s\n", err.Error())
+	}
+
+	codes, _ := registry.ResolveCurrent(540422)
+	if len(codes) != 1 || codes[0] != 540481 {
+		t.Errorf("ResolveCurrent(540422): %v\n", codes)
+	}
+}
+
+// go test -v -run="TestStore$"
+func TestStore(t *testing.T) {
+	store, err := Store()
+	if err != nil {
+		t.Fatalf("Store error: %s\n", err.Error())
+	}
+
+	version := store.At(time.Date(2022, 12, 31, 0, 0, 0, 0, time.Local))
+	if version == nil || version.Name != "2022" {
+		t.Errorf("At(2022-12-31): %v\n", version)
+	}
+	// 生效日期为北京时间，和主机的时区无关
+	version = store.At(time.Date(2022, 12, 31, 15, 59, 59, 0, time.UTC))
+	if version == nil || version.Name != "2022" {
+		t.Errorf("At(2022-12-31T15:59:59Z): %v\n", version)
+	}
+	version = store.At(time.Date(2022, 12, 31, 16, 0, 0, 0, time.UTC))
+	if version == nil || version.Name != "2023" {
+		t.Errorf("At(2022-12-31T16:00:00Z): %v\n", version)
+	}
+	version = store.At(time.Now())
+	if version == nil || version.Name != strconv.Itoa(LatestYear()) {
+		t.Errorf("At(now): %v\n", version)
+	}
+}
+
+// go test -v -run="TestSameAsRoot$"
+func TestSameAsRoot(t *testing.T) {
+	paths, _ := filepath.Glob("../../district-*.csv")
+	if len(paths) == 0 {
+		t.Skip("no district data files in the repository root")
+	}
+
+	rootYears := make(map[int]bool)
+	for _, path := range paths {
+		var year int
+		if _, err := fmt.Sscanf(filepath.Base(path), "district-%d.csv", &year); err != nil {
+			continue
+		}
+		rootYears[year] = true
+
+		rootData, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error: %s\n", path, err.Error())
+		}
+		data, err := Bytes(year)
+		if err != nil {
+			t.Errorf("%s is not embedded, run go generate\n", path)
+		} else if !bytes.Equal(data, rootData) {
+			t.Errorf("%s differs from the embedded one, run go generate\n", path)
+		}
+	}
+	for _, year := range Years() {
+		if !rootYears[year] {
+			t.Errorf("district-%d.csv is embedded but not in the repository root\n", year)
+		}
+	}
+}
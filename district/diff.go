@@ -0,0 +1,260 @@
+// Package district
+package district
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChangeKind 行政区变更类型
+type ChangeKind string
+
+const (
+	ChangeAdded      ChangeKind = "added"      // 新增
+	ChangeRemoved    ChangeKind = "removed"    // 删除
+	ChangeRenamed    ChangeKind = "renamed"    // 更名（代码不变，名称变化）
+	ChangeRecoded    ChangeKind = "recoded"    // 变更代码（同一上级下，名称相同或仅后缀变化，代码变化）
+	ChangeReparented ChangeKind = "reparented" // 变更上级（代码不变，上级行政区变化）
+)
+
+// changeKinds 变更类型的输出顺序
+var changeKinds = []ChangeKind{ChangeAdded, ChangeRemoved, ChangeRenamed, ChangeRecoded, ChangeReparented}
+
+// changeTitles Markdown 输出时各变更类型的标题，风格同 CHANGES.md
+var changeTitles = map[ChangeKind]string{
+	ChangeAdded:      "新增的",
+	ChangeRemoved:    "删除的",
+	ChangeRenamed:    "更名的",
+	ChangeRecoded:    "变更代码的",
+	ChangeReparented: "变更上级的",
+}
+
+// Change 一个行政区的变更
+type Change struct {
+	Kind      ChangeKind `json:"kind"`
+	Code      uint32     `json:"code,omitempty"`     // 新数据中的代码，删除时为 0
+	OldCode   uint32     `json:"old_code,omitempty"` // 旧数据中的代码，新增时为 0
+	Name      string     `json:"name,omitempty"`     // 新数据中的名称，删除时为空
+	OldName   string     `json:"old_name,omitempty"` // 旧数据中的名称，新增时为空
+	Level     uint32     `json:"level"`
+	Parent    uint32     `json:"parent,omitempty"`     // 新数据中的上级行政区代码
+	OldParent uint32     `json:"old_parent,omitempty"` // 旧数据中的上级行政区代码
+}
+
+// ChangeSet 两份行政区数据间的变更集
+type ChangeSet struct {
+	Changes []Change `json:"changes"`
+}
+
+// flatDistrict 展开后的行政区，Parent 为直接上级行政区的代码
+type flatDistrict struct {
+	Code   uint32
+	Name   string
+	Level  uint32
+	Parent uint32
+}
+
+// Diff 比较新旧两份行政区数据，得到结构化的变更集
+func Diff(oldTable, newTable *Table) *ChangeSet {
+	oldDistricts := flattenTable(oldTable)
+	newDistricts := flattenTable(newTable)
+	changeSet := &ChangeSet{Changes: make([]Change, 0)}
+
+	// 代码相同的，比较名称和上级
+	removed := make([]flatDistrict, 0)
+	for code, o := range oldDistricts {
+		n, ok := newDistricts[code]
+		if !ok {
+			removed = append(removed, o)
+			continue
+		}
+		if o.Name != n.Name {
+			changeSet.add(ChangeRenamed, &o, &n)
+		}
+		if o.Parent != n.Parent {
+			changeSet.add(ChangeReparented, &o, &n)
+		}
+	}
+	added := make(map[uint32]flatDistrict)
+	for code, n := range newDistricts {
+		if _, ok := oldDistricts[code]; !ok {
+			added[code] = n
+		}
+	}
+
+	// 被删除的，在同一上级下找名称相同或仅后缀变化的新增行政区，如：540422,米林县 => 540481,米林市
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].Code < removed[j].Code
+	})
+	for _, o := range removed {
+		if n, ok := findRecoded(&o, added); ok {
+			changeSet.add(ChangeRecoded, &o, &n)
+			delete(added, n.Code)
+		} else {
+			changeSet.add(ChangeRemoved, &o, nil)
+		}
+	}
+	for _, n := range added {
+		changeSet.add(ChangeAdded, nil, &n)
+	}
+
+	sort.SliceStable(changeSet.Changes, func(i, j int) bool {
+		ci, cj := &changeSet.Changes[i], &changeSet.Changes[j]
+		if ci.Kind != cj.Kind {
+			return kindOrder(ci.Kind) < kindOrder(cj.Kind)
+		}
+		return ci.code() < cj.code()
+	})
+	return changeSet
+}
+
+// Count 取得指定类型的变更数
+func (c *ChangeSet) Count(kind ChangeKind) int {
+	count := 0
+	for _, change := range c.Changes {
+		if change.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// Markdown 以 Markdown 格式输出变更集，格式同 CHANGES.md
+func (c *ChangeSet) Markdown() string {
+	var builder strings.Builder
+	for _, kind := range changeKinds {
+		if c.Count(kind) == 0 {
+			continue
+		}
+		if builder.Len() > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(fmt.Sprintf("# %s：\n\n", changeTitles[kind]))
+		for _, change := range c.Changes {
+			if change.Kind == kind {
+				builder.WriteString(fmt.Sprintf("* %s\n", change.describe()))
+			}
+		}
+	}
+	return builder.String()
+}
+
+// String 以文本方式输出变更集，每个变更一行
+func (c *ChangeSet) String() string {
+	var builder strings.Builder
+	for _, change := range c.Changes {
+		builder.WriteString(fmt.Sprintf("%s %s\n", change.Kind, change.describe()))
+	}
+	return builder.String()
+}
+
+func (c *ChangeSet) add(kind ChangeKind, o, n *flatDistrict) {
+	change := Change{Kind: kind}
+	if o != nil {
+		change.OldCode = o.Code
+		change.OldName = o.Name
+		change.OldParent = o.Parent
+		change.Level = o.Level
+	}
+	if n != nil {
+		change.Code = n.Code
+		change.Name = n.Name
+		change.Parent = n.Parent
+		change.Level = n.Level
+	}
+	c.Changes = append(c.Changes, change)
+}
+
+// code 用于排序的代码，优先取新代码
+func (c *Change) code() uint32 {
+	if c.Code != 0 {
+		return c.Code
+	}
+	return c.OldCode
+}
+
+// describe 变更的描述，如：540422,米林县 => 540481,米林市
+func (c *Change) describe() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("%d,%s", c.Code, c.Name)
+	case ChangeRemoved:
+		return fmt.Sprintf("%d,%s", c.OldCode, c.OldName)
+	case ChangeReparented:
+		return fmt.Sprintf("%d,%s: %d => %d", c.Code, c.Name, c.OldParent, c.Parent)
+	default:
+		return fmt.Sprintf("%d,%s => %d,%s", c.OldCode, c.OldName, c.Code, c.Name)
+	}
+}
+
+func kindOrder(kind ChangeKind) int {
+	for i, k := range changeKinds {
+		if k == kind {
+			return i
+		}
+	}
+	return len(changeKinds)
+}
+
+// findRecoded 在新增的行政区中找与 o 同一上级、同级别且名称相同或仅后缀变化的
+func findRecoded(o *flatDistrict, added map[uint32]flatDistrict) (flatDistrict, bool) {
+	var candidate flatDistrict
+	found := false
+	for _, n := range added {
+		if n.Parent != o.Parent || n.Level != o.Level {
+			continue
+		}
+		if n.Name == o.Name || trimDistrictSuffix(n.Name) == trimDistrictSuffix(o.Name) {
+			// 多个候选时取代码最小的，保证结果稳定
+			if !found || n.Code < candidate.Code {
+				candidate = n
+				found = true
+			}
+		}
+	}
+	return candidate, found
+}
+
+// trimDistrictSuffix 去掉行政区名的县/市/区/旗等后缀
+func trimDistrictSuffix(name string) string {
+	for _, suffix := range []string{"县", "市", "区", "旗"} {
+		if strings.HasSuffix(name, suffix) && len([]rune(name)) > 2 {
+			return strings.TrimSuffix(name, suffix)
+		}
+	}
+	return name
+}
+
+// flattenTable 将行政区表展开为以代码为键的表
+func flattenTable(table *Table) map[uint32]flatDistrict {
+	districts := make(map[uint32]flatDistrict)
+	for _, provinceDistrict := range table.Provinces {
+		districts[provinceDistrict.Code] = flatDistrict{
+			Code:  provinceDistrict.Code,
+			Name:  provinceDistrict.Name,
+			Level: provinceDistrict.Level,
+		}
+		for _, cityDistrict := range provinceDistrict.Cities {
+			districts[cityDistrict.Code] = flatDistrict{
+				Code:   cityDistrict.Code,
+				Name:   cityDistrict.Name,
+				Level:  cityDistrict.Level,
+				Parent: provinceDistrict.Code,
+			}
+			for _, countyDistrict := range cityDistrict.Counties {
+				if countyDistrict.Synthetic {
+					// 合成代码不是官方代码，不同数据中相同的合成代码不一定是同一行政区
+					continue
+				}
+				districts[countyDistrict.Code] = flatDistrict{
+					Code:   countyDistrict.Code,
+					Name:   countyDistrict.Name,
+					Level:  countyDistrict.Level,
+					Parent: cityDistrict.Code,
+				}
+			}
+		}
+	}
+	return districts
+}
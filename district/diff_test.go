@@ -0,0 +1,110 @@
+// Package district
+package district
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const testOldDistrictData = `行政区划代码,单位名称
+540000,西藏自治区
+540400,林芝市
+540422,米林县
+540426,朗县
+540500,山南市
+540530,错那县
+650000,新疆维吾尔自治区
+659011,胡杨河市`
+
+const testNewDistrictData = `行政区划代码,单位名称
+540000,西藏自治区
+540400,林芝市
+540481,米林市
+540426,朗县X
+540500,山南市
+650000,新疆维吾尔自治区
+659011,胡杨河市
+659012,白杨市`
+
+// go test -v -run="TestDiff$"
+func TestDiff(t *testing.T) {
+	ctx := context.Background()
+	oldTable, err := LoadDistrictFromReader(ctx, strings.NewReader(testOldDistrictData))
+	if err != nil {
+		t.Fatalf("load old error: %s\n", err.Error())
+	}
+	newTable, err := LoadDistrictFromReader(ctx, strings.NewReader(testNewDistrictData))
+	if err != nil {
+		t.Fatalf("load new error: %s\n", err.Error())
+	}
+
+	changeSet := Diff(oldTable, newTable)
+	t.Logf("\n%s", changeSet.Markdown())
+
+	expects := []string{
+		"added 659012,白杨市",
+		"removed 540530,错那县",
+		"renamed 540426,朗县 => 540426,朗县X",
+		"recoded 540422,米林县 => 540481,米林市",
+	}
+	lines := strings.Split(strings.TrimSpace(changeSet.String()), "\n")
+	if len(lines) != len(expects) {
+		t.Fatalf("changes:\n%s", changeSet.String())
+	}
+	for i, expect := range expects {
+		if lines[i] != expect {
+			t.Errorf("change %d: %s, expect %s\n", i, lines[i], expect)
+		}
+	}
+
+	if changeSet = Diff(newTable, newTable); len(changeSet.Changes) != 0 {
+		t.Errorf("diff of same table:\n%s", changeSet.String())
+	}
+}
+
+// go test -v -run="TestMigrationSql$"
+func TestMigrationSql(t *testing.T) {
+	ctx := context.Background()
+	oldTable, _ := LoadDistrictFromReader(ctx, strings.NewReader(testOldDistrictData))
+	newTable, _ := LoadDistrictFromReader(ctx, strings.NewReader(testNewDistrictData))
+
+	sql := MigrationSql(oldTable, newTable, "t_dict_district")
+	t.Logf("\n%s", sql)
+
+	expects := []string{
+		"START TRANSACTION;",
+		"DELETE FROM t_dict_district WHERE f_province_code=540000 AND f_city_code=540400 AND f_county_code=540422;",
+		"UPDATE t_dict_district SET f_level=3,f_province_name='西藏自治区',f_city_name='林芝市',f_county_name='朗县X' WHERE f_province_code=540000 AND f_city_code=540400 AND f_county_code=540426;",
+		"INSERT INTO t_dict_district (f_province_code,f_city_code,f_county_code,f_level,f_province_name,f_city_name,f_county_name) VALUES (650000,659012,0,3,'新疆维吾尔自治区','白杨市','');",
+		"COMMIT;",
+	}
+	for _, expect := range expects {
+		if !strings.Contains(sql, expect+"\n") {
+			t.Errorf("missing: %s\n", expect)
+		}
+	}
+	if !strings.HasPrefix(sql, "-- DELETE: 2, UPDATE: 1, INSERT: 2\n") {
+		t.Errorf("unexpected summary: %s\n", strings.SplitN(sql, "\n", 2)[0])
+	}
+}
+
+// go test -v -run="TestMigrationSqlWithOptions$"
+func TestMigrationSqlWithOptions(t *testing.T) {
+	ctx := context.Background()
+	oldTable, _ := LoadDistrictFromReader(ctx, strings.NewReader(testOldDistrictData))
+	newTable, _ := LoadDistrictFromReader(ctx, strings.NewReader(testNewDistrictData))
+
+	sql := MigrationSqlWithOptions(oldTable, newTable, SqlOptions{TableName: "t_dict_district", WithPinyin: true, WithPostal: true})
+	t.Logf("\n%s", sql)
+
+	expects := []string{
+		"UPDATE t_dict_district SET f_level=3,f_province_name='西藏自治区',f_city_name='林芝市',f_county_name='朗县X',f_pinyin='langxianx',f_pinyin_initials='lxx',f_english_name='Langxianx',f_area_code='',f_postal_code='' WHERE f_province_code=540000 AND f_city_code=540400 AND f_county_code=540426;",
+		"INSERT INTO t_dict_district (f_province_code,f_city_code,f_county_code,f_level,f_province_name,f_city_name,f_county_name,f_pinyin,f_pinyin_initials,f_english_name,f_area_code,f_postal_code) VALUES (650000,659012,0,3,'新疆维吾尔自治区','白杨市','','baiyangshi','bys','Baiyang Shi','','');",
+	}
+	for _, expect := range expects {
+		if !strings.Contains(sql, expect+"\n") {
+			t.Errorf("missing: %s\n", expect)
+		}
+	}
+}
@@ -4,12 +4,13 @@ package district
 
 import (
     "bufio"
+    "compress/gzip"
     "context"
-    "encoding/json"
     "fmt"
     "golang.org/x/text/collate"
     "golang.org/x/text/language"
     "io"
+    "io/fs"
     "os"
     "sort"
     "strconv"
@@ -27,9 +28,15 @@ type Table struct {
 // ProvinceDistrict 省/自治区/直辖市
 type ProvinceDistrict struct {
     Code              uint32                  `json:"code"`
-    Name              string                  `json:"name"`         // 行政区名称
-    Level             uint32                  `json:"level"`        // 行政区级别（1 省/自治区/直辖市，2 市/州/盟，3 县/县级市/旗）
-    Municipality      bool                    `json:"municipality"` // 直辖市
+    Name              string                  `json:"name"`                  // 行政区名称
+    Level             uint32                  `json:"level"`                 // 行政区级别（1 省/自治区/直辖市，2 市/州/盟，3 县/县级市/旗）
+    Municipality      bool                    `json:"municipality"`          // 直辖市
+    Abbreviation      string                  `json:"abbreviation"`          // 简称，如：粤
+    Pinyin            string                  `json:"pinyin"`                // 全拼，如：guangdongsheng
+    PinyinInitials    string                  `json:"pinyin_initials"`       // 拼音首字母，如：gds
+    EnglishName       string                  `json:"english_name"`          // 英文名，缺省为罗马字拼写，如：Guangdong Sheng
+    AreaCode          string                  `json:"area_code,omitempty"`   // 电话区号，需 SetPostalInfo
+    PostalCode        string                  `json:"postal_code,omitempty"` // 邮政编码，需 SetPostalInfo
     CityDistrictTable map[uint32]CityDistrict `json:"-"`
     Cities            []CityDistrict          `json:"cities,omitempty"`
 }
@@ -37,24 +44,47 @@ type ProvinceDistrict struct {
 // CityDistrict 市/州/盟
 type CityDistrict struct {
     Code                uint32              `json:"code"`
-    Name                string              `json:"name"`        // 行政区名称
-    Level               uint32              `json:"level"`       // 行政区级别（1 省/自治区/直辖市，2 市/州/盟，3 县/县级市/旗）
-    CountyCity          bool                `json:"county_city"` // 县级市
+    Name                string              `json:"name"`                  // 行政区名称
+    Level               uint32              `json:"level"`                 // 行政区级别（1 省/自治区/直辖市，2 市/州/盟，3 县/县级市/旗）
+    CountyCity          bool                `json:"county_city"`           // 县级市
+    Pinyin              string              `json:"pinyin"`                // 全拼，如：zhuhaishi
+    PinyinInitials      string              `json:"pinyin_initials"`       // 拼音首字母，如：zhs
+    EnglishName         string              `json:"english_name"`          // 英文名，缺省为罗马字拼写，如：Zhuhai Shi
+    AreaCode            string              `json:"area_code,omitempty"`   // 电话区号，需 SetPostalInfo ，如：0756
+    PostalCode          string              `json:"postal_code,omitempty"` // 邮政编码，需 SetPostalInfo ，如：519000
+    Kind                DistrictKind        `json:"kind"`                  // 类别（行政区、功能区或汇总码下的），如：雄安新区为功能区
     CountyDistrictTable map[uint32]District `json:"-"`
     Counties            []District          `json:"counties,omitempty"`
+    TownshipTable       map[uint64]Township `json:"-"`
+    Townships           []Township          `json:"townships,omitempty"` // 直辖市的区县、省直辖县级市和不设区的市（如：东莞市）的乡级行政区
 }
 
 type District struct {
-    Code        uint32 `json:"code"`        // 行政区代码
-    Name        string `json:"name"`        // 行政区名称
-    Level       uint32 `json:"level"`       // 行政区级别（1 省/自治区/直辖市，2 市/州/盟，3 县/县级市/旗）
-    Parent      uint32 `json:"parent"`      // 父行政区代码
-    Grandparent uint32 `json:"grandparent"` // 父父行政区代码
+    Code        uint32       `json:"code"`                // 行政区代码
+    Name        string       `json:"name"`                // 行政区名称
+    Level       uint32       `json:"level"`               // 行政区级别（1 省/自治区/直辖市，2 市/州/盟，3 县/县级市/旗）
+    Parent      uint32       `json:"parent"`              // 父行政区代码
+    Grandparent uint32       `json:"grandparent"`         // 父父行政区代码
+    Kind        DistrictKind `json:"kind"`                // 类别（行政区、功能区、汇总码下的或合成代码的），如：经开区为功能区
+    Uncoded     bool         `json:"uncoded,omitempty"`   // 数据源中无编码（如：西沙区），代码来自补充映射文件或为合成代码
+    Synthetic   bool         `json:"synthetic,omitempty"` // 代码为合成代码，非官方代码，Kind 为 KindSynthetic
+
+    Pinyin         string `json:"pinyin"`                // 全拼，如：xiangzhouqu
+    PinyinInitials string `json:"pinyin_initials"`       // 拼音首字母，如：xzq
+    EnglishName    string `json:"english_name"`          // 英文名，缺省为罗马字拼写，如：Xiangzhou Qu
+    AreaCode       string `json:"area_code,omitempty"`   // 电话区号，需 SetPostalInfo
+    PostalCode     string `json:"postal_code,omitempty"` // 邮政编码，需 SetPostalInfo
+
+    TownshipTable map[uint64]Township `json:"-"`
+    Townships     []Township          `json:"townships,omitempty"` // 乡级行政区（乡/镇/街道）
 }
 
 func LoadDistrict(ctx context.Context, filepath string) (*Table, error) {
-    var districtTable Table
+    return LoadDistrictWithOptions(ctx, filepath, LoadOptions{})
+}
 
+// LoadDistrictWithOptions 按选项加载行政区数据，如不含功能区
+func LoadDistrictWithOptions(ctx context.Context, filepath string, options LoadOptions) (*Table, error) {
     // 打开文件
     file, err := os.Open(filepath)
     if err != nil {
@@ -62,20 +92,56 @@ func LoadDistrict(ctx context.Context, filepath string) (*Table, error) {
     }
     defer file.Close()
 
+    return LoadDistrictFromReaderWithOptions(ctx, file, options)
+}
+
+// LoadDistrictFS 从 fs.FS（如 embed.FS）加载行政区数据
+func LoadDistrictFS(ctx context.Context, fsys fs.FS, name string) (*Table, error) {
+    file, err := fsys.Open(name)
+    if err != nil {
+        return nil, err
+    }
+    defer file.Close()
+
+    return LoadDistrictFromReader(ctx, file)
+}
+
+// LoadDistrictFromReader 从 io.Reader 加载行政区数据，数据格式同 LoadDistrict 的文件，
+// gzip 压缩的数据会自动解压，ctx 被取消时中止加载
+func LoadDistrictFromReader(ctx context.Context, r io.Reader) (*Table, error) {
+    return LoadDistrictFromReaderWithOptions(ctx, r, LoadOptions{})
+}
+
+// LoadDistrictFromReaderWithOptions 按选项从 io.Reader 加载行政区数据，被排除的行政区的下级也被排除
+func LoadDistrictFromReaderWithOptions(ctx context.Context, r io.Reader, options LoadOptions) (*Table, error) {
+    var districtTable Table
+    excludedCodes := make(map[uint32]bool) // 被排除的行政区代码
+    lastCode := uint32(0)                  // 上一个行政区的代码，用于确定无编码行的上级
+
     // 创建一个带缓冲的读取器
-    reader := bufio.NewReader(file)
+    reader, err := newBufferedReader(r)
+    if err != nil {
+        return nil, err
+    }
 
     // 按行读取文件内容
     lineNo := 0
     districtTable.ProvinceDistrictTable = make(map[uint32]ProvinceDistrict)
     for {
+        if err := ctx.Err(); err != nil {
+            return nil, err
+        }
+
         lineNo = lineNo + 1
         line, err := reader.ReadString('\n')
         if err != nil {
-            if err == io.EOF {
+            if err != io.EOF {
+                return nil, err
+            }
+            if len(line) == 0 {
                 break
             }
-            return nil, err
+            // 最后一行没有换行符
         }
 
         line = strings.Trim(line, "\n")
@@ -85,66 +151,39 @@ func LoadDistrict(ctx context.Context, filepath string) (*Table, error) {
             continue
         }
 
-        district, err := parseLine(lineNo, line)
+        // 被排除的行政区的下级
+        if code := lineDistrictCode(line); excludedCodes[code] || excludedCodes[getCityDistrictCode(code)] {
+            continue
+        }
+
+        var district *District
+        if isStatisticalLine(line) {
+            // 国家统计局的统计用区划代码，含乡级和村级
+            district, err = parseStatisticalLine(&districtTable, lineNo, line)
+        } else {
+            district, err = parseLine(lineNo, line)
+        }
+        if err == nil && district == nil {
+            if name := uncodedName(line); len(name) > 0 {
+                // 无编码的行，如：,西沙区
+                district, err = parseUncodedLine(&districtTable, lineNo, name, lastCode, &options)
+            }
+        }
         if err != nil {
             return nil, err
         } else {
             if district == nil {
                 continue
             }
+            lastCode = district.Code
+            if options.excluded(district.Kind) {
+                excludedCodes[district.Code] = true
+                continue
+            }
             //fmt.Println(*district)
 
-            provinceCode := getProvinceDistrictCode(district.Code)
-            cityCode := getCityDistrictCode(district.Code)
-            if IsProvinceDistrictCode(district.Code) {
-                // 省/自治区/直辖市
-                provinceDistrict := ProvinceDistrict{
-                    Code:              district.Code,
-                    Name:              district.Name,
-                    Level:             district.Level,
-                    CityDistrictTable: make(map[uint32]CityDistrict),
-                    Municipality:      IsMunicipalityCode(district.Code),
-                }
-                districtTable.ProvinceDistrictTable[provinceCode] = provinceDistrict
-            } else if IsCityDistrictCode(district.Code) {
-                // 市/州/盟
-                cityDistrict := CityDistrict{
-                    Code:                district.Code,
-                    Name:                district.Name,
-                    Level:               district.Level,
-                    CountyDistrictTable: make(map[uint32]District),
-                    CountyCity:          false,
-                }
-                districtTable.ProvinceDistrictTable[provinceCode].CityDistrictTable[cityCode] = cityDistrict
-            } else if IsCountyDistrictCode(district.Code) {
-                if !IsMunicipalityCode(district.Code) {
-                    // 非直辖市
-                    if districtTable.ProvinceDistrictTable[provinceCode].CityDistrictTable[cityCode].CountyDistrictTable == nil {
-                        // 省直辖县级市（济源市，河南省直辖县级市；五指山市，海南省直辖县级市）
-                        cityDistrict := CityDistrict{
-                            Code:  district.Code,
-                            Name:  district.Name,
-                            Level: district.Level,
-                            //CountyDistrictTable: make(map[uint32]District),
-                            CountyCity: true,
-                        }
-                        districtTable.ProvinceDistrictTable[provinceCode].CityDistrictTable[district.Code] = cityDistrict
-                    } else {
-                        // 县/县级市/旗
-                        districtTable.ProvinceDistrictTable[provinceCode].CityDistrictTable[cityCode].CountyDistrictTable[district.Code] = *district
-                    }
-                } else {
-                    // 直辖市的区县
-                    cityDistrict := CityDistrict{
-                        Code:                district.Code,
-                        Name:                district.Name,
-                        Level:               district.Level - 1,
-                        CountyDistrictTable: make(map[uint32]District),
-                    }
-                    districtTable.ProvinceDistrictTable[provinceCode].CityDistrictTable[district.Code] = cityDistrict
-                }
-            } else {
-                return nil, fmt.Errorf("invalid row data: (%d) %s", lineNo, line)
+            if err := addDistrict(&districtTable, lineNo, line, district); err != nil {
+                return nil, err
             }
         }
     }
@@ -153,102 +192,211 @@ func LoadDistrict(ctx context.Context, filepath string) (*Table, error) {
     return &districtTable, nil
 }
 
-func GenerateJson(districtTable *Table, jsonFilepath string, withIndent bool, indent, prefix string) error {
-    var err error
-    var jsonBytes []byte
-    filepath := jsonFilepath
-
-    if !withIndent {
-        jsonBytes, err = json.Marshal(*districtTable)
+// addDistrict 将省市县三级的行政区加入行政区表，上级行政区需已加入
+func addDistrict(districtTable *Table, lineNo int, line string, district *District) error {
+    provinceCode := getProvinceDistrictCode(district.Code)
+    cityCode := getCityDistrictCode(district.Code)
+    if IsProvinceDistrictCode(district.Code) {
+        // 省/自治区/直辖市
+        provinceDistrict := ProvinceDistrict{
+            Code:              district.Code,
+            Name:              district.Name,
+            Level:             district.Level,
+            CityDistrictTable: make(map[uint32]CityDistrict),
+            Municipality:      IsMunicipalityCode(district.Code),
+            Abbreviation:      ProvinceAbbreviation(district.Code),
+            Pinyin:            district.Pinyin,
+            PinyinInitials:    district.PinyinInitials,
+            EnglishName:       district.EnglishName,
+        }
+        districtTable.ProvinceDistrictTable[provinceCode] = provinceDistrict
+    } else if IsCityDistrictCode(district.Code) {
+        // 市/州/盟
+        cityDistrict := CityDistrict{
+            Code:                district.Code,
+            Name:                district.Name,
+            Level:               district.Level,
+            CountyDistrictTable: make(map[uint32]District),
+            CountyCity:          false,
+            Kind:                district.Kind,
+            Pinyin:              district.Pinyin,
+            PinyinInitials:      district.PinyinInitials,
+            EnglishName:         district.EnglishName,
+        }
+        districtTable.ProvinceDistrictTable[provinceCode].CityDistrictTable[cityCode] = cityDistrict
+    } else if IsCountyDistrictCode(district.Code) {
+        if !IsMunicipalityCode(district.Code) {
+            // 非直辖市
+            if districtTable.ProvinceDistrictTable[provinceCode].CityDistrictTable[cityCode].CountyDistrictTable == nil {
+                // 省直辖县级市（济源市，河南省直辖县级市；五指山市，海南省直辖县级市）
+                cityDistrict := CityDistrict{
+                    Code:  district.Code,
+                    Name:  district.Name,
+                    Level: district.Level,
+                    //CountyDistrictTable: make(map[uint32]District),
+                    CountyCity:     true,
+                    Kind:           district.Kind,
+                    Pinyin:         district.Pinyin,
+                    PinyinInitials: district.PinyinInitials,
+                    EnglishName:    district.EnglishName,
+                }
+                districtTable.ProvinceDistrictTable[provinceCode].CityDistrictTable[district.Code] = cityDistrict
+            } else {
+                // 县/县级市/旗
+                districtTable.ProvinceDistrictTable[provinceCode].CityDistrictTable[cityCode].CountyDistrictTable[district.Code] = *district
+            }
+        } else {
+            // 直辖市的区县
+            cityDistrict := CityDistrict{
+                Code:                district.Code,
+                Name:                district.Name,
+                Level:               district.Level - 1,
+                CountyDistrictTable: make(map[uint32]District),
+                Kind:                district.Kind,
+                Pinyin:              district.Pinyin,
+                PinyinInitials:      district.PinyinInitials,
+                EnglishName:         district.EnglishName,
+            }
+            districtTable.ProvinceDistrictTable[provinceCode].CityDistrictTable[district.Code] = cityDistrict
+        }
     } else {
-        jsonBytes, err = json.MarshalIndent(*districtTable, prefix, indent)
-    }
-    if err != nil {
-        return fmt.Errorf("json marshal error: %s", err.Error())
+        return fmt.Errorf("invalid row data: (%d) %s", lineNo, line)
     }
+    return nil
+}
 
-    file, writer := createFile(filepath)
-    if file == nil {
-        return fmt.Errorf("create file://%s error: %s", filepath, err.Error())
-    }
-    defer file.Close()
+func GenerateJson(districtTable *Table, jsonFilepath string, withIndent bool, indent, prefix string) error {
+    return generateFile(jsonFilepath, func(w io.Writer) error {
+        return WriteJson(districtTable, w, withIndent, indent, prefix)
+    })
+}
 
-    _, err = writer.WriteString(string(jsonBytes))
-    if err != nil {
-        return fmt.Errorf("write file://%s error: %s", filepath, err.Error())
-    }
-    err = writer.Flush()
-    if err != nil {
-        return fmt.Errorf("flush file://%s error: %s", filepath, err.Error())
-    }
+// WriteJson 将 json 格式数据写到 w ，如标准输出
+func WriteJson(districtTable *Table, w io.Writer, withIndent bool, indent, prefix string) error {
+    return writeJsonValue(w, *districtTable, withIndent, indent, prefix)
+}
 
-    return nil
+// CsvOptions 生成 csv 格式数据的选项
+type CsvOptions struct {
+    Delimiter  string // 分隔符，为空时为逗号
+    WithCode   bool   // 是否输出行政区代码列
+    WithPinyin bool   // 是否输出拼音、拼音首字母和英文名列
+    WithPostal bool   // 是否输出电话区号和邮政编码列
 }
 
 func GenerateCsv(districtTable *Table, csvFilepath, csvDelimiter string, withCode bool) error {
-    var err error
+    return GenerateCsvWithOptions(districtTable, csvFilepath, CsvOptions{Delimiter: csvDelimiter, WithCode: withCode})
+}
+
+// GenerateCsvWithOptions 按选项生成 csv 格式数据文件
+func GenerateCsvWithOptions(districtTable *Table, csvFilepath string, options CsvOptions) error {
+    return generateFile(csvFilepath, func(w io.Writer) error {
+        return WriteCsv(districtTable, w, options)
+    })
+}
+
+// WriteCsv 将 csv 格式数据写到 w ，如标准输出
+func WriteCsv(districtTable *Table, w io.Writer, options CsvOptions) error {
     var builder strings.Builder
-    filepath := csvFilepath
-    file, writer := createFile(filepath)
-    if file == nil {
-        return fmt.Errorf("create file://%s error: %s", filepath, err.Error())
+    csvDelimiter := options.Delimiter
+    if len(csvDelimiter) == 0 {
+        csvDelimiter = ","
     }
-    defer file.Close()
+    withCode := options.WithCode
+    withPinyin := options.WithPinyin
+    withPostal := options.WithPostal
 
     for _, provinceDistrict := range districtTable.Provinces {
         if !withCode {
-            builder.WriteString(fmt.Sprintf("%s\n", provinceDistrict.Name))
+            builder.WriteString(provinceDistrict.Name)
         } else {
-            builder.WriteString(fmt.Sprintf("%d%s%s\n", provinceDistrict.Code, csvDelimiter, provinceDistrict.Name))
+            builder.WriteString(fmt.Sprintf("%d%s%s", provinceDistrict.Code, csvDelimiter, provinceDistrict.Name))
+        }
+        if withPinyin {
+            builder.WriteString(csvPinyinColumns(csvDelimiter,
+                provinceDistrict.Pinyin, provinceDistrict.PinyinInitials, provinceDistrict.EnglishName))
         }
+        if withPostal {
+            builder.WriteString(fmt.Sprintf("%s%s%s%s", csvDelimiter, provinceDistrict.AreaCode, csvDelimiter, provinceDistrict.PostalCode))
+        }
+        builder.WriteString("\n")
+
         for _, cityDistrict := range provinceDistrict.Cities {
             if !withCode {
-                builder.WriteString(fmt.Sprintf("%s%s%s\n",
+                builder.WriteString(fmt.Sprintf("%s%s%s",
                     provinceDistrict.Name, csvDelimiter, cityDistrict.Name))
             } else {
-                builder.WriteString(fmt.Sprintf("%d%s%s%s%s\n",
+                builder.WriteString(fmt.Sprintf("%d%s%s%s%s",
                     cityDistrict.Code, csvDelimiter,
                     provinceDistrict.Name, csvDelimiter, cityDistrict.Name))
             }
+            if withPinyin {
+                builder.WriteString(csvPinyinColumns(csvDelimiter,
+                    cityDistrict.Pinyin, cityDistrict.PinyinInitials, cityDistrict.EnglishName))
+            }
+            if withPostal {
+                builder.WriteString(fmt.Sprintf("%s%s%s%s", csvDelimiter, cityDistrict.AreaCode, csvDelimiter, cityDistrict.PostalCode))
+            }
+            builder.WriteString("\n")
 
             for _, countyDistrict := range cityDistrict.Counties {
                 if !withCode {
-                    builder.WriteString(fmt.Sprintf("%s%s%s%s%s\n",
+                    builder.WriteString(fmt.Sprintf("%s%s%s%s%s",
                         provinceDistrict.Name, csvDelimiter,
                         cityDistrict.Name, csvDelimiter, countyDistrict.Name))
                 } else {
-                    builder.WriteString(fmt.Sprintf("%d%s%s%s%s%s%s\n",
+                    builder.WriteString(fmt.Sprintf("%d%s%s%s%s%s%s",
                         countyDistrict.Code, csvDelimiter,
                         provinceDistrict.Name, csvDelimiter,
                         cityDistrict.Name, csvDelimiter, countyDistrict.Name))
                 }
-                if err != nil {
-                    return fmt.Errorf("write file://%s error: %s", filepath, err.Error())
+                if withPinyin {
+                    builder.WriteString(csvPinyinColumns(csvDelimiter,
+                        countyDistrict.Pinyin, countyDistrict.PinyinInitials, countyDistrict.EnglishName))
+                }
+                if withPostal {
+                    builder.WriteString(fmt.Sprintf("%s%s%s%s", csvDelimiter, countyDistrict.AreaCode, csvDelimiter, countyDistrict.PostalCode))
                 }
+                builder.WriteString("\n")
             }
         }
     }
-    _, err = writer.WriteString(builder.String())
-    if err != nil {
-        return fmt.Errorf("write file://%s error: %s", filepath, err.Error())
-    }
-    err = writer.Flush()
-    if err != nil {
-        return fmt.Errorf("flush file://%s error: %s", filepath, err.Error())
-    }
 
-    return nil
+    _, err := io.WriteString(w, builder.String())
+    return err
+}
+
+// SqlOptions 生成 sql 数据的选项
+type SqlOptions struct {
+    TableName  string // 表名，为空时为 t_dict_district
+    WithIgnore bool   // 是否使用“INSERT IGNORE INTO”忽略已存在的
+    WithPinyin bool   // 是否输出拼音、拼音首字母和英文名列
+    WithPostal bool   // 是否输出电话区号和邮政编码列
 }
 
 func GenerateSql(districtTable *Table, sqlFilepath, tableName string, withIgnore bool) error {
-    var err error
+    return GenerateSqlWithOptions(districtTable, sqlFilepath, SqlOptions{TableName: tableName, WithIgnore: withIgnore})
+}
+
+// GenerateSqlWithOptions 按选项生成 sql 数据文件
+func GenerateSqlWithOptions(districtTable *Table, sqlFilepath string, options SqlOptions) error {
+    return generateFile(sqlFilepath, func(w io.Writer) error {
+        return WriteSql(districtTable, w, options)
+    })
+}
+
+// WriteSql 将 sql 插入语句写到 w ，如标准输出
+func WriteSql(districtTable *Table, w io.Writer, options SqlOptions) error {
     var builder strings.Builder
-    filepath := sqlFilepath
-    file, writer := createFile(filepath)
-    if file == nil {
-        return fmt.Errorf("create file://%s error: %s", filepath, err.Error())
+    tableName := options.TableName
+    if len(tableName) == 0 {
+        tableName = "t_dict_district"
     }
-    defer file.Close()
+    withIgnore := options.WithIgnore
+    withPinyin := options.WithPinyin
+    withPostal := options.WithPostal
+    withTownship := districtTable.HasTownships()
+    withVillage := withTownship && districtTable.HasVillages()
 
     // 要求的表格式：
     builder.WriteString("/*\n")
@@ -261,7 +409,31 @@ func GenerateSql(districtTable *Table, sqlFilepath, tableName string, withIgnore
     builder.WriteString("  f_province_name VARCHAR(20) NOT NULL,\n")
     builder.WriteString("  f_city_name VARCHAR(20) NOT NULL,\n")
     builder.WriteString("  f_county_name VARCHAR(20) NOT NULL,\n")
-    builder.WriteString("  PRIMARY KEY (f_province_code,f_city_code,f_county_code),\n")
+    if withPinyin {
+        // 行所在行政区（最低一级）的拼音和英文名
+        builder.WriteString("  f_pinyin VARCHAR(64) NOT NULL,\n")
+        builder.WriteString("  f_pinyin_initials VARCHAR(20) NOT NULL,\n")
+        builder.WriteString("  f_english_name VARCHAR(100) NOT NULL,\n")
+    }
+    if withPostal {
+        // 行所在行政区（最低一级）的电话区号和邮政编码
+        builder.WriteString("  f_area_code VARCHAR(8) NOT NULL,\n")
+        builder.WriteString("  f_postal_code VARCHAR(8) NOT NULL,\n")
+    }
+    if withTownship {
+        // 乡级（级别为 4）和村级（级别为 5）的行，省市县三级的行值为 0 和空
+        builder.WriteString("  f_township_code BIGINT UNSIGNED NOT NULL DEFAULT 0,\n")
+        builder.WriteString("  f_township_name VARCHAR(50) NOT NULL DEFAULT '',\n")
+        if withVillage {
+            builder.WriteString("  f_village_code BIGINT UNSIGNED NOT NULL DEFAULT 0,\n")
+            builder.WriteString("  f_village_name VARCHAR(100) NOT NULL DEFAULT '',\n")
+            builder.WriteString("  PRIMARY KEY (f_province_code,f_city_code,f_county_code,f_township_code,f_village_code),\n")
+        } else {
+            builder.WriteString("  PRIMARY KEY (f_province_code,f_city_code,f_county_code,f_township_code),\n")
+        }
+    } else {
+        builder.WriteString("  PRIMARY KEY (f_province_code,f_city_code,f_county_code),\n")
+    }
     builder.WriteString("  KEY (f_province_name),\n")
     builder.WriteString("  KEY (f_city_name),\n")
     builder.WriteString("  KEY (f_county_name)\n")
@@ -275,24 +447,47 @@ func GenerateSql(districtTable *Table, sqlFilepath, tableName string, withIgnore
     }
     for _, provinceDistrict := range districtTable.Provinces {
         // 省/自治区/直辖市
-        line := fmt.Sprintf("(%d,%d,%d,%d,'%s','%s','%s'),\n",
+        line := fmt.Sprintf("(%d,%d,%d,%d,'%s','%s','%s'%s%s%s),\n",
             provinceDistrict.Code, 0, 0, provinceDistrict.Level,
-            provinceDistrict.Name, "", "")
+            provinceDistrict.Name, "", "",
+            sqlPinyinValues(withPinyin, provinceDistrict.Pinyin, provinceDistrict.PinyinInitials, provinceDistrict.EnglishName),
+            sqlPostalValues(withPostal, provinceDistrict.AreaCode, provinceDistrict.PostalCode),
+            sqlTownshipValues(withTownship, withVillage, nil, nil))
         builder.WriteString(line)
 
         for _, cityDistrict := range provinceDistrict.Cities {
             // 市/州/盟
-            line := fmt.Sprintf("(%d,%d,%d,%d,'%s','%s','%s'),\n",
+            line := fmt.Sprintf("(%d,%d,%d,%d,'%s','%s','%s'%s%s%s),\n",
                 provinceDistrict.Code, cityDistrict.Code, 0, cityDistrict.Level,
-                provinceDistrict.Name, cityDistrict.Name, "")
+                provinceDistrict.Name, cityDistrict.Name, "",
+                sqlPinyinValues(withPinyin, cityDistrict.Pinyin, cityDistrict.PinyinInitials, cityDistrict.EnglishName),
+                sqlPostalValues(withPostal, cityDistrict.AreaCode, cityDistrict.PostalCode),
+                sqlTownshipValues(withTownship, withVillage, nil, nil))
             builder.WriteString(line)
 
+            // 直辖市的区县、省直辖县级市和不设区的市的乡级和村级
+            builder.WriteString(sqlTownshipRows(
+                fmt.Sprintf("%d,%d,%d", provinceDistrict.Code, cityDistrict.Code, 0),
+                fmt.Sprintf("'%s','%s','%s'", provinceDistrict.Name, cityDistrict.Name, ""),
+                sqlPinyinValues(withPinyin, "", "", "")+sqlPostalValues(withPostal, cityDistrict.AreaCode, cityDistrict.PostalCode),
+                cityDistrict.Townships, withVillage))
+
             for _, countyDistrict := range cityDistrict.Counties {
                 // 县/县级市/旗
-                line := fmt.Sprintf("(%d,%d,%d,%d,'%s','%s','%s'),\n",
+                line := fmt.Sprintf("(%d,%d,%d,%d,'%s','%s','%s'%s%s%s),\n",
                     provinceDistrict.Code, cityDistrict.Code, countyDistrict.Code, countyDistrict.Level,
-                    provinceDistrict.Name, cityDistrict.Name, countyDistrict.Name)
+                    provinceDistrict.Name, cityDistrict.Name, countyDistrict.Name,
+                    sqlPinyinValues(withPinyin, countyDistrict.Pinyin, countyDistrict.PinyinInitials, countyDistrict.EnglishName),
+                    sqlPostalValues(withPostal, countyDistrict.AreaCode, countyDistrict.PostalCode),
+                    sqlTownshipValues(withTownship, withVillage, nil, nil))
                 builder.WriteString(line)
+
+                // 乡/镇/街道和村
+                builder.WriteString(sqlTownshipRows(
+                    fmt.Sprintf("%d,%d,%d", provinceDistrict.Code, cityDistrict.Code, countyDistrict.Code),
+                    fmt.Sprintf("'%s','%s','%s'", provinceDistrict.Name, cityDistrict.Name, countyDistrict.Name),
+                    sqlPinyinValues(withPinyin, "", "", "")+sqlPostalValues(withPostal, countyDistrict.AreaCode, countyDistrict.PostalCode),
+                    countyDistrict.Townships, withVillage))
             }
         }
     }
@@ -300,20 +495,43 @@ func GenerateSql(districtTable *Table, sqlFilepath, tableName string, withIgnore
     sql := strings.Trim(builder.String(), "\n")
     sql = strings.Trim(sql, ",")
     sql = sql + ";"
-    _, err = writer.WriteString(sql)
-    if err != nil {
-        return fmt.Errorf("write file://%s error: %s", filepath, err.Error())
-    }
+    _, err := io.WriteString(w, sql)
+    return err
+}
 
-    err = writer.Flush()
-    if err != nil {
-        return fmt.Errorf("flush file://%s error: %s", filepath, err.Error())
+// WriteDistrict 将行政区数据写成数据源文件的格式（行政区划代码,单位名称），
+// 如将统计用区划代码格式或 gzip 压缩的数据源文件转换为省市县三级的数据源文件
+func WriteDistrict(districtTable *Table, w io.Writer) error {
+    var builder strings.Builder
+
+    builder.WriteString("行政区划代码,单位名称\n")
+    for _, row := range districtTable.Rows() {
+        code := Code{ProvinceCode: row.ProvinceCode, CityCode: row.CityCode, CountyCode: row.CountyCode}
+        name := row.ProvinceName
+        if row.CountyCode != 0 {
+            name = row.CountyName
+        } else if row.CityCode != 0 {
+            name = row.CityName
+        }
+        builder.WriteString(fmt.Sprintf("%d,%s\n", code.Lowest(), name))
     }
 
-    return nil
+    _, err := io.WriteString(w, builder.String())
+    return err
+}
+
+// XlsxOptions 生成 xlsx 数据的选项
+type XlsxOptions struct {
+    WithPinyin bool // 是否增加拼音、拼音首字母和英文名的工作表
 }
 
 func GenerateXlsx(districtTable *Table, xlsxFilepath string) error {
+    return GenerateXlsxWithOptions(districtTable, xlsxFilepath, XlsxOptions{})
+}
+
+// GenerateXlsxWithOptions 按选项生成 xlsx 数据文件
+func GenerateXlsxWithOptions(districtTable *Table, xlsxFilepath string, options XlsxOptions) error {
+    withPinyin := options.WithPinyin
     ctx := context.Background()
     sheetName := "mooon-district"
 
@@ -443,10 +661,46 @@ func GenerateXlsx(districtTable *Table, xlsxFilepath string) error {
         columnNumber++
     }
 
-    err = setSheet1(f)
+    // 乡级行政区名，公式名称为市级和县级行政区名相连（如：珠海市香洲区），
+    // 直辖市的区县、省直辖县级市和不设区的市没有县级行政区，公式名称为市级行政区名
+    withTownship := districtTable.HasTownships()
+    if withTownship {
+        // 空一列
+        columnNumber++
+
+        for _, provinceDistrict := range districtTable.Provinces {
+            for _, cityDistrict := range provinceDistrict.Cities {
+                err = setTownshipColumn(f, sheetName, columnNumber, cityDistrict.Name, cityDistrict.Townships)
+                if err != nil {
+                    return err
+                }
+                if len(cityDistrict.Townships) > 0 {
+                    columnNumber++
+                }
+
+                for _, countyDistrict := range cityDistrict.Counties {
+                    err = setTownshipColumn(f, sheetName, columnNumber, cityDistrict.Name+countyDistrict.Name, countyDistrict.Townships)
+                    if err != nil {
+                        return err
+                    }
+                    if len(countyDistrict.Townships) > 0 {
+                        columnNumber++
+                    }
+                }
+            }
+        }
+    }
+
+    err = setSheet1(f, withTownship)
     if err != nil {
         return err
     }
+    if withPinyin {
+        err = setPinyinSheet(f, districtTable)
+        if err != nil {
+            return err
+        }
+    }
     err = f.SaveAs(xlsxFilepath)
     if err != nil {
         return fmt.Errorf("save %s error: %s", xlsxFilepath, err.Error())
@@ -454,6 +708,66 @@ func GenerateXlsx(districtTable *Table, xlsxFilepath string) error {
     return nil
 }
 
+// csvPinyinColumns 取得 csv 行的拼音、拼音首字母和英文名列
+func csvPinyinColumns(csvDelimiter, pinyin, pinyinInitials, englishName string) string {
+    return fmt.Sprintf("%s%s%s%s%s%s", csvDelimiter, pinyin, csvDelimiter, pinyinInitials, csvDelimiter, englishName)
+}
+
+// sqlTownshipValues 取得 INSERT 语句的乡级和村级值，township 或 village 为 nil 时值为 0 和空
+func sqlTownshipValues(withTownship, withVillage bool, township *Township, village *Village) string {
+    if !withTownship {
+        return ""
+    }
+
+    var values string
+    if township == nil {
+        values = ",0,''"
+    } else {
+        values = fmt.Sprintf(",%d,'%s'", township.Code, township.Name)
+    }
+    if withVillage {
+        if village == nil {
+            values += ",0,''"
+        } else {
+            values += fmt.Sprintf(",%d,'%s'", village.Code, village.Name)
+        }
+    }
+    return values
+}
+
+// sqlTownshipRows 取得乡级和村级的 INSERT 值，codes 和 names 为所在省市县的代码和名称，extra 为拼音等附加列的值
+func sqlTownshipRows(codes, names, extra string, townships []Township, withVillage bool) string {
+    var builder strings.Builder
+    for i := range townships {
+        township := &townships[i]
+        builder.WriteString(fmt.Sprintf("(%s,%d,%s%s%s),\n",
+            codes, township.Level, names, extra, sqlTownshipValues(true, withVillage, township, nil)))
+
+        for j := range township.Villages {
+            village := &township.Villages[j]
+            builder.WriteString(fmt.Sprintf("(%s,%d,%s%s%s),\n",
+                codes, village.Level, names, extra, sqlTownshipValues(true, withVillage, township, village)))
+        }
+    }
+    return builder.String()
+}
+
+// sqlPostalValues 取得 INSERT 语句的电话区号和邮政编码值
+func sqlPostalValues(withPostal bool, areaCode, postalCode string) string {
+    if !withPostal {
+        return ""
+    }
+    return fmt.Sprintf(",'%s','%s'", areaCode, postalCode)
+}
+
+// sqlPinyinValues 取得 INSERT 语句的拼音、拼音首字母和英文名值，英文名中可能有单引号（如：Xi'an）
+func sqlPinyinValues(withPinyin bool, pinyin, pinyinInitials, englishName string) string {
+    if !withPinyin {
+        return ""
+    }
+    return fmt.Sprintf(",'%s','%s','%s'", pinyin, pinyinInitials, strings.ReplaceAll(englishName, "'", "''"))
+}
+
 func parseLine(lineNo int, line string) (*District, error) {
     // 使用逗号分隔每行数据
     parts := strings.Split(line, ",")
@@ -493,6 +807,11 @@ func parseLine(lineNo int, line string) (*District, error) {
         Level:       level,
         Parent:      uint32(parent),
         Grandparent: uint32(grandparent),
+        Kind:        ClassifyDistrict(uint32(code), name),
+
+        Pinyin:         strings.Join(Pinyin(name), ""),
+        PinyinInitials: PinyinInitials(name),
+        EnglishName:    Romanize(name),
     }, nil
 }
 
@@ -535,8 +854,10 @@ func perfectTable(table *Table) {
     for _, provinceDistrict := range table.ProvinceDistrictTable {
         for _, cityDistrict := range provinceDistrict.CityDistrictTable {
             for _, countyDistrict := range cityDistrict.CountyDistrictTable {
+                countyDistrict.Townships = perfectTownships(countyDistrict.TownshipTable)
                 cityDistrict.Counties = append(cityDistrict.Counties, countyDistrict)
             }
+            cityDistrict.Townships = perfectTownships(cityDistrict.TownshipTable)
             sort.Slice(cityDistrict.Counties, func(i, j int) bool {
                 return cityDistrict.Counties[i].Code < cityDistrict.Counties[j].Code
             })
@@ -552,6 +873,24 @@ func perfectTable(table *Table) {
     })
 }
 
+// newBufferedReader 创建带缓冲的读取器，如果是 gzip 压缩的数据则自动解压
+func newBufferedReader(r io.Reader) (*bufio.Reader, error) {
+    reader := bufio.NewReader(r)
+    magic, err := reader.Peek(2)
+    if err != nil && err != io.EOF {
+        return nil, err
+    }
+    if len(magic) < 2 || magic[0] != 0x1f || magic[1] != 0x8b {
+        return reader, nil
+    }
+
+    gzipReader, err := gzip.NewReader(reader)
+    if err != nil {
+        return nil, fmt.Errorf("gzip reader error: %s", err.Error())
+    }
+    return bufio.NewReader(gzipReader), nil
+}
+
 func createFile(filepath string) (*os.File, *bufio.Writer) {
     file, err := os.Create(filepath)
     if err != nil {
@@ -562,6 +901,26 @@ func createFile(filepath string) (*os.File, *bufio.Writer) {
     return file, bufio.NewWriter(file)
 }
 
+// generateFile 创建文件，将 write 生成的数据写入文件
+func generateFile(filepath string, write func(w io.Writer) error) error {
+    file, writer := createFile(filepath)
+    if file == nil {
+        return fmt.Errorf("create file://%s error", filepath)
+    }
+    defer file.Close()
+
+    err := write(writer)
+    if err != nil {
+        return fmt.Errorf("write file://%s error: %s", filepath, err.Error())
+    }
+    err = writer.Flush()
+    if err != nil {
+        return fmt.Errorf("flush file://%s error: %s", filepath, err.Error())
+    }
+
+    return nil
+}
+
 func createXlsxFile(ctx context.Context, sheetName string) (*excelize.File, error) {
     // 创建一个新的Excel文件
     f := excelize.NewFile()
@@ -596,7 +955,38 @@ func sortStrByPinyin(strArray []string) {
     })
 }
 
-func setSheet1(f *excelize.File) error {
+// setTownshipColumn 在第 columnNumber 列写入乡级行政区名，并创建名为 definedName 的公式名称，townships 为空时什么也不做
+func setTownshipColumn(f *excelize.File, sheetName string, columnNumber int, definedName string, townships []Township) error {
+    if len(townships) == 0 {
+        return nil
+    }
+
+    townshipNameArray := make([]string, 0, len(townships))
+    for _, township := range townships {
+        townshipNameArray = append(townshipNameArray, township.Name)
+    }
+    sortStrByPinyin(townshipNameArray)
+
+    columnName, _ := excelize.ColumnNumberToName(columnNumber)
+    lineNo := 1
+    f.SetCellStr(sheetName, fmt.Sprintf("%s%d", columnName, lineNo), definedName)
+    lineNo++
+    for _, townshipName := range townshipNameArray {
+        f.SetCellStr(sheetName, fmt.Sprintf("%s%d", columnName, lineNo), townshipName)
+        lineNo++
+    }
+
+    err := f.SetDefinedName(&excelize.DefinedName{
+        Name:     definedName,
+        RefersTo: fmt.Sprintf("'%s'!$%s$%d:$%s$%d", sheetName, columnName, 2, columnName, lineNo-1),
+    })
+    if err != nil {
+        return fmt.Errorf("set defined name of townships error: (%s) %s", definedName, err.Error())
+    }
+    return nil
+}
+
+func setSheet1(f *excelize.File, withTownship bool) error {
     sheetName := "Sheet1"
 
     // 标题行样式
@@ -620,6 +1010,11 @@ func setSheet1(f *excelize.File) error {
     f.SetCellStr(sheetName, "A1", "省级行政区\n（下拉选择）")
     f.SetCellStr(sheetName, "B1", "市级行政区\n（下拉选择）")
     f.SetCellStr(sheetName, "C1", "县级行政区\n（下拉选择）")
+    lastColumnName := "C"
+    if withTownship {
+        f.SetCellStr(sheetName, "D1", "乡级行政区\n（下拉选择）")
+        lastColumnName = "D"
+    }
 
     // 将加粗字体样式应用于整个第一行范围
     err = f.SetRowStyle(sheetName, 1, 1, titleStyle)
@@ -628,7 +1023,7 @@ func setSheet1(f *excelize.File) error {
     }
 
     // 设置列宽度
-    err = f.SetColWidth(sheetName, "A", "C", 20)
+    err = f.SetColWidth(sheetName, "A", lastColumnName, 20)
     if err != nil {
         return fmt.Errorf("set column width error: %s", err.Error())
     }
@@ -661,7 +1056,68 @@ func setSheet1(f *excelize.File) error {
         if err := f.AddDataValidation(sheetName, dvRange3); err != nil {
             return fmt.Errorf("add data validation of counties error: %s", err.Error())
         }
+
+        // 数据行 - 乡级行政区
+        // 在 D2 单元格中添加数据验证
+        if withTownship {
+            dvRange4 := excelize.NewDataValidation(true)
+            dvRange4.Sqref = fmt.Sprintf("D%d:D%d", i, i)
+            dvRange4.SetSqrefDropList(fmt.Sprintf("INDIRECT(B%d&C%d)", i, i))
+            if err := f.AddDataValidation(sheetName, dvRange4); err != nil {
+                return fmt.Errorf("add data validation of townships error: %s", err.Error())
+            }
+        }
     }
 
     return nil
-}
\ No newline at end of file
+}
+
+// setPinyinSheet 增加行政区的拼音和英文名工作表，每行一个行政区
+func setPinyinSheet(f *excelize.File, districtTable *Table) error {
+    sheetName := "pinyin"
+    _, err := f.NewSheet(sheetName)
+    if err != nil {
+        return fmt.Errorf("new sheet %s error: %s", sheetName, err.Error())
+    }
+
+    lineNo := 1
+    setRow := func(values ...interface{}) error {
+        err := f.SetSheetRow(sheetName, fmt.Sprintf("A%d", lineNo), &values)
+        if err != nil {
+            return fmt.Errorf("set row %d of %s error: %s", lineNo, sheetName, err.Error())
+        }
+        lineNo++
+        return nil
+    }
+
+    // 标题行
+    err = setRow("行政区代码", "省级行政区", "市级行政区", "县级行政区", "拼音", "拼音首字母", "英文名")
+    if err != nil {
+        return err
+    }
+    for _, provinceDistrict := range districtTable.Provinces {
+        err = setRow(provinceDistrict.Code, provinceDistrict.Name, "", "",
+            provinceDistrict.Pinyin, provinceDistrict.PinyinInitials, provinceDistrict.EnglishName)
+        if err != nil {
+            return err
+        }
+
+        for _, cityDistrict := range provinceDistrict.Cities {
+            err = setRow(cityDistrict.Code, provinceDistrict.Name, cityDistrict.Name, "",
+                cityDistrict.Pinyin, cityDistrict.PinyinInitials, cityDistrict.EnglishName)
+            if err != nil {
+                return err
+            }
+
+            for _, countyDistrict := range cityDistrict.Counties {
+                err = setRow(countyDistrict.Code, provinceDistrict.Name, cityDistrict.Name, countyDistrict.Name,
+                    countyDistrict.Pinyin, countyDistrict.PinyinInitials, countyDistrict.EnglishName)
+                if err != nil {
+                    return err
+                }
+            }
+        }
+    }
+
+    return nil
+}
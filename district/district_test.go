@@ -0,0 +1,133 @@
+// Package district
+package district
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const testDistrictData = `行政区划代码,单位名称
+440000,广东省
+440400,珠海市
+440402,香洲区
+440403,斗门区
+441900,东莞市
+110000,北京市
+110108,海淀区`
+
+// go test -v -run="TestLoadDistrictFromReader$"
+func TestLoadDistrictFromReader(t *testing.T) {
+	ctx := context.Background()
+
+	// 最后一行没有换行符
+	table, err := LoadDistrictFromReader(ctx, strings.NewReader(testDistrictData))
+	if err != nil {
+		t.Fatalf("LoadDistrictFromReader error: %s\n", err.Error())
+	}
+	checkTestTable(t, table)
+
+	// gzip 压缩的数据
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	_, _ = gzipWriter.Write([]byte(testDistrictData))
+	_ = gzipWriter.Close()
+	table, err = LoadDistrictFromReader(ctx, &buf)
+	if err != nil {
+		t.Fatalf("LoadDistrictFromReader(gzip) error: %s\n", err.Error())
+	}
+	checkTestTable(t, table)
+
+	// 已取消的 ctx
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = LoadDistrictFromReader(canceledCtx, strings.NewReader(testDistrictData))
+	if err != context.Canceled {
+		t.Errorf("LoadDistrictFromReader(canceled): %v\n", err)
+	}
+}
+
+// go test -v -run="TestLoadDistrictFS$"
+func TestLoadDistrictFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"district.csv": &fstest.MapFile{Data: []byte(testDistrictData)},
+	}
+
+	table, err := LoadDistrictFS(context.Background(), fsys, "district.csv")
+	if err != nil {
+		t.Fatalf("LoadDistrictFS error: %s\n", err.Error())
+	}
+	checkTestTable(t, table)
+
+	_, err = LoadDistrictFS(context.Background(), fsys, "district-x.csv")
+	if err == nil {
+		t.Errorf("LoadDistrictFS(district-x.csv): expect error\n")
+	}
+}
+
+func checkTestTable(t *testing.T, table *Table) {
+	if len(table.Provinces) != 2 {
+		t.Errorf("provinces: %d\n", len(table.Provinces))
+		return
+	}
+	if len(table.Provinces[0].Cities) != 1 || table.Provinces[0].Cities[0].Name != "海淀区" {
+		t.Errorf("cities of 北京市: %v\n", table.Provinces[0].Cities)
+	}
+	if len(table.Provinces[1].Cities) != 2 || len(table.Provinces[1].Cities[0].Counties) != 2 {
+		t.Errorf("cities of 广东省: %v\n", table.Provinces[1].Cities)
+	}
+}
+
+// go test -v -run="TestWriteCsv$"
+func TestWriteCsv(t *testing.T) {
+	table, err := LoadDistrictFromReader(context.Background(), strings.NewReader(testDistrictData))
+	if err != nil {
+		t.Fatalf("LoadDistrictFromReader error: %s\n", err.Error())
+	}
+
+	var buf bytes.Buffer
+	if err = WriteCsv(table, &buf, CsvOptions{Delimiter: ",", WithCode: true}); err != nil {
+		t.Fatalf("WriteCsv error: %s\n", err.Error())
+	}
+	if !strings.Contains(buf.String(), "440402,广东省,珠海市,香洲区\n") {
+		t.Errorf("WriteCsv: %s\n", buf.String())
+	}
+
+	buf.Reset()
+	if err = WriteCsv(table, &buf, CsvOptions{Delimiter: "|", WithPostal: true}); err != nil {
+		t.Fatalf("WriteCsv error: %s\n", err.Error())
+	}
+	if !strings.Contains(buf.String(), "广东省|珠海市|香洲区||\n") {
+		t.Errorf("WriteCsv with postal: %s\n", buf.String())
+	}
+
+	buf.Reset()
+	if err = WriteSql(table, &buf, SqlOptions{TableName: "t_dict_district"}); err != nil {
+		t.Fatalf("WriteSql error: %s\n", err.Error())
+	}
+	if !strings.HasSuffix(buf.String(), "(440000,441900,0,2,'广东省','东莞市','');") {
+		t.Errorf("WriteSql: %s\n", buf.String())
+	}
+}
+
+// go test -v -run="TestWriteDistrict$"
+func TestWriteDistrict(t *testing.T) {
+	ctx := context.Background()
+	table, err := LoadDistrictFromReader(ctx, strings.NewReader(testDistrictData))
+	if err != nil {
+		t.Fatalf("LoadDistrictFromReader error: %s\n", err.Error())
+	}
+
+	var buf bytes.Buffer
+	if err = WriteDistrict(table, &buf); err != nil {
+		t.Fatalf("WriteDistrict error: %s\n", err.Error())
+	}
+	table, err = LoadDistrictFromReader(ctx, &buf)
+	if err != nil {
+		t.Fatalf("LoadDistrictFromReader(WriteDistrict) error: %s\n", err.Error())
+	}
+	checkTestTable(t, table)
+}
@@ -16,6 +16,7 @@ import (
 	"runtime/debug"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -26,6 +27,7 @@ type Query struct {
 	Db            *gorm.DB
 	TableName     string
 	ExpireSeconds int
+	normalizer    atomic.Pointer[Normalizer] // Load2Cache 后才有，用于支持简名，可能被其它协程并发读取
 }
 
 // CacheMetric 缓存的度量数据
@@ -87,6 +89,17 @@ func (d *Code) Md5Sum() string {
 	return Md5Sum(data)
 }
 
+// Lowest 取得最低一级的 6 位行政区代码，如：{440000, 440400, 440402} => 440402
+func (d *Code) Lowest() uint32 {
+	if d.CountyCode != 0 {
+		return d.CountyCode
+	}
+	if d.CityCode != 0 {
+		return d.CityCode
+	}
+	return d.ProvinceCode
+}
+
 func Md5Sum(data string) string {
 	hash := md5.Sum([]byte(data))
 	return strings.ToLower(hex.EncodeToString(hash[:]))
@@ -164,11 +177,13 @@ func (q *Query) Load2Cache() (int, error) {
 		}
 	}
 
+	q.normalizer.Store(NewNormalizer(results))
+
 	// 返回成功条数
 	return len(results), nil
 }
 
-// GetDistrictCode 通过行政区名取得行政区代码
+// GetDistrictCode 通过行政区名取得行政区代码，调用过 Load2Cache 后行政区名可为简名，如：广东/珠海/香洲
 // 返回值：
 // 1）成功返回非 nil 的 DistrictCode，同时 error 值为 nil ；
 // 2）不存在返回 nil 的 DistrictCode，同时 error 值为 nil ；
@@ -180,6 +195,13 @@ func (q *Query) GetDistrictCode(ctx context.Context, name *Name) (*Code, error)
 	}
 
 	code, err = q.getDistrictCodeFromDb(ctx, name)
+	if normalizer := q.normalizer.Load(); err == nil && code == nil && normalizer != nil {
+		// 简名或后缀不同的，如：广东/珠海/香洲，结果也以原名缓存，以免每次都查库和规范化
+		normalizedName := normalizer.Normalize(name)
+		if normalizedName != nil && *normalizedName != *name {
+			code, err = q.GetDistrictCode(ctx, normalizedName)
+		}
+	}
 	if err == nil && code != nil {
 		_ = q.updateDistrictCodeToCache(name, code)
 	}
@@ -218,6 +240,20 @@ func (q *Query) GetCountyCount(ctx context.Context, provinceName, cityName strin
 	return count, err
 }
 
+// GetChildren 取得下一级行政区列表
+func (q *Query) GetChildren(ctx context.Context, code *Code) ([]DictDistrict, error) {
+	children, err := q.getChildrenFromCache(code)
+	if err == nil {
+		return children, nil
+	}
+
+	children, err = q.getChildrenFromDb(ctx, code)
+	if err == nil {
+		_ = q.updateChildrenToCache(code, children)
+	}
+	return children, err
+}
+
 // getDistrictCodeFromDb 从数据库中取得行政区代码
 func (q *Query) getDistrictCodeFromDb(ctx context.Context, name *Name) (*Code, error) {
 	var code Code
@@ -259,7 +295,7 @@ func (q *Query) getCountyCountFromDb(ctx context.Context, provinceName, cityName
 	var count int64
 
 	err := q.Db.WithContext(ctx).Table(q.TableName).
-		Where("f_province_name = ? AND f_city_name = ?", provinceName, cityName).
+		Where("f_province_name = ? AND f_city_name = ? AND f_level <= 3", provinceName, cityName).
 		Count(&count).
 		Error
 	if err != nil {
@@ -269,6 +305,31 @@ func (q *Query) getCountyCountFromDb(ctx context.Context, provinceName, cityName
 	return int(count), nil
 }
 
+// getChildrenFromDb 从数据库中取得下一级行政区列表
+func (q *Query) getChildrenFromDb(ctx context.Context, code *Code) ([]DictDistrict, error) {
+	results := make([]DictDistrict, 0)
+	db := q.Db.WithContext(ctx).Table(q.TableName)
+
+	// 表中可能有乡级和村级的行（级别为 4 和 5）
+	db = db.Where("f_level <= 3")
+	if code.ProvinceCode == 0 {
+		db = db.Where("f_city_code = 0 AND f_county_code = 0")
+	} else if code.CityCode == 0 {
+		db = db.Where("f_province_code = ? AND f_city_code <> 0 AND f_county_code = 0", code.ProvinceCode)
+	} else if code.CountyCode == 0 {
+		db = db.Where("f_province_code = ? AND f_city_code = ? AND f_county_code <> 0", code.ProvinceCode, code.CityCode)
+	} else {
+		return results, nil // 县级行政区没有下一级
+	}
+
+	err := db.Order("f_province_code, f_city_code, f_county_code").Find(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
 // getDistrictCodeFromCache 从缓存中取得行政区代码
 func (q *Query) getDistrictCodeFromCache(name *Name) (*Code, error) {
 	var code Code
@@ -339,8 +400,7 @@ func (q *Query) updateDistrictNameToCache(code *Code, name *Name) error {
 
 // getCountyCountFromCache 从缓存中取得区县数量
 func (q *Query) getCountyCountFromCache(provinceName, cityName string) (int, error) {
-	key := "CountyCount:" + provinceName + ":" + cityName
-	cacheKey := Md5Sum(key)
+	cacheKey := Md5Sum(countyCountKey(provinceName, cityName))
 
 	data, err := districtCache.Get([]byte(cacheKey))
 	if err != nil {
@@ -357,8 +417,7 @@ func (q *Query) getCountyCountFromCache(provinceName, cityName string) (int, err
 
 // updateCountyCountToCache 将区县数量更新到缓存
 func (q *Query) updateCountyCountToCache(provinceName, cityName string, countyCount int) error {
-	key := "CountyCount:" + provinceName + ":" + cityName
-	cacheKey := Md5Sum(key)
+	cacheKey := Md5Sum(countyCountKey(provinceName, cityName))
 
 	randSeconds := getRandSeconds(q.ExpireSeconds)
 	err := districtCache.Set([]byte(cacheKey), []byte(strconv.Itoa(countyCount)), q.ExpireSeconds+randSeconds)
@@ -369,6 +428,40 @@ func (q *Query) updateCountyCountToCache(provinceName, cityName string, countyCo
 	return nil
 }
 
+// getChildrenFromCache 从缓存中取得下一级行政区列表
+func (q *Query) getChildrenFromCache(code *Code) ([]DictDistrict, error) {
+	var children []DictDistrict
+	cacheKey := Md5Sum("Children:" + code.Md5Sum())
+	jsonBytes, err := districtCache.Get([]byte(cacheKey))
+	if err != nil {
+		return nil, fmt.Errorf("cache get error: %s", err.Error())
+	}
+
+	err = json.Unmarshal(jsonBytes, &children)
+	if err != nil {
+		return nil, fmt.Errorf("cache json unmarshal error: %s", err.Error())
+	}
+
+	return children, nil
+}
+
+// updateChildrenToCache 将下一级行政区列表更新到缓存
+func (q *Query) updateChildrenToCache(code *Code, children []DictDistrict) error {
+	cacheKey := Md5Sum("Children:" + code.Md5Sum())
+	jsonBytes, err := json.Marshal(children)
+	if err != nil {
+		return fmt.Errorf("cache json marshal error: %s", err.Error())
+	}
+
+	randSeconds := getRandSeconds(q.ExpireSeconds)
+	err = districtCache.Set([]byte(cacheKey), jsonBytes, q.ExpireSeconds+randSeconds)
+	if err != nil {
+		return fmt.Errorf("cache set error: %s", err.Error())
+	}
+
+	return nil
+}
+
 // getRandSeconds 随机获取缓存过期时间，防止同一时间过期
 func getRandSeconds(expireSeconds int) int {
 	randSeconds := 1
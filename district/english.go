@@ -0,0 +1,106 @@
+// Package district
+package district
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadEnglishNames 从映射文件加载行政区的官方英文名，用于覆盖缺省的罗马字拼写（如：内蒙古自治区 => Inner Mongolia），
+// 每行格式为：行政区代码,英文名，首个非注释行为标题时跳过，以 # 开头的行为注释
+func LoadEnglishNames(ctx context.Context, filepath string) (map[uint32]string, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return LoadEnglishNamesFromReader(ctx, file)
+}
+
+// LoadEnglishNamesFromReader 从 io.Reader 加载行政区的官方英文名，格式同 LoadEnglishNames
+func LoadEnglishNamesFromReader(ctx context.Context, r io.Reader) (map[uint32]string, error) {
+	englishNames := make(map[uint32]string)
+	reader, err := newBufferedReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	lineNo := 0
+	titled := false // 已过首个非注释行
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		lineNo = lineNo + 1
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			if len(line) == 0 {
+				break
+			}
+		}
+
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// 英文名中可能有逗号，只按第一个逗号分隔
+		parts := strings.SplitN(line, ",", 2)
+		if len(parts) != 2 || len(strings.TrimSpace(parts[1])) == 0 {
+			return nil, fmt.Errorf("invalid row format: (%d) %s, expected format: DistrictCode,EnglishName", lineNo, line)
+		}
+		code, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 32)
+		if err != nil {
+			if !titled {
+				titled = true
+				continue // 标题行
+			}
+			return nil, fmt.Errorf("invalid district code: (%d) %s", lineNo, line)
+		}
+		titled = true
+		englishNames[uint32(code)] = strings.TrimSpace(parts[1])
+	}
+
+	return englishNames, nil
+}
+
+// SetEnglishNames 用官方英文名覆盖行政区的缺省英文名，englishNames 的键为 6 位行政区代码，
+// 不在 englishNames 中的行政区保持不变
+func (t *Table) SetEnglishNames(englishNames map[uint32]string) {
+	for i := range t.Provinces {
+		provinceDistrict := &t.Provinces[i]
+		if englishName, ok := englishNames[provinceDistrict.Code]; ok {
+			provinceDistrict.EnglishName = englishName
+			p := t.ProvinceDistrictTable[provinceDistrict.Code]
+			p.EnglishName = englishName
+			t.ProvinceDistrictTable[provinceDistrict.Code] = p
+		}
+
+		for j := range provinceDistrict.Cities {
+			cityDistrict := &provinceDistrict.Cities[j]
+			if englishName, ok := englishNames[cityDistrict.Code]; ok {
+				cityDistrict.EnglishName = englishName
+				c := provinceDistrict.CityDistrictTable[cityDistrict.Code]
+				c.EnglishName = englishName
+				provinceDistrict.CityDistrictTable[cityDistrict.Code] = c
+			}
+
+			for k := range cityDistrict.Counties {
+				countyDistrict := &cityDistrict.Counties[k]
+				if englishName, ok := englishNames[countyDistrict.Code]; ok {
+					countyDistrict.EnglishName = englishName
+					cityDistrict.CountyDistrictTable[countyDistrict.Code] = *countyDistrict
+				}
+			}
+		}
+	}
+}
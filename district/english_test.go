@@ -0,0 +1,64 @@
+// Package district
+package district
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// go test -v -run="TestRomanize$"
+func TestRomanize(t *testing.T) {
+	cases := map[string]string{
+		"广东省":        "Guangdong Sheng",
+		"珠海市":        "Zhuhai Shi",
+		"香洲区":        "Xiangzhou Qu",
+		"内蒙古自治区":     "Neimenggu Zizhiqu",
+		"恩施土家族苗族自治州": "Enshi Tujiazu Miaozu Zizhizhou",
+		"东区":         "Dongqu",
+	}
+	for name, expect := range cases {
+		if englishName := Romanize(name); englishName != expect {
+			t.Errorf("Romanize(%s): %s, expect %s\n", name, englishName, expect)
+		}
+	}
+}
+
+// go test -v -run="TestSetEnglishNames$"
+func TestSetEnglishNames(t *testing.T) {
+	ctx := context.Background()
+	table, err := LoadDistrictFromReader(ctx, strings.NewReader(testDistrictData))
+	if err != nil {
+		t.Fatalf("LoadDistrictFromReader error: %s\n", err.Error())
+	}
+	county := table.ProvinceDistrictTable[440000].CityDistrictTable[440400].CountyDistrictTable[440402]
+	if county.Pinyin != "xiangzhouqu" || county.PinyinInitials != "xzq" || county.EnglishName != "Xiangzhou Qu" {
+		t.Errorf("440402: %s %s %s\n", county.Pinyin, county.PinyinInitials, county.EnglishName)
+	}
+
+	englishNames, err := LoadEnglishNamesFromReader(ctx, strings.NewReader("code,english_name\n# 注释\n440000,Guangdong\n440402,Xiangzhou District"))
+	if err != nil {
+		t.Fatalf("LoadEnglishNamesFromReader error: %s\n", err.Error())
+	}
+	table.SetEnglishNames(englishNames)
+
+	if englishName := table.ProvinceDistrictTable[440000].EnglishName; englishName != "Guangdong" {
+		t.Errorf("ProvinceDistrictTable[440000]: %s\n", englishName)
+	}
+	if englishName := table.Provinces[1].EnglishName; englishName != "Guangdong" {
+		t.Errorf("Provinces[1]: %s\n", englishName)
+	}
+	if englishName := table.Provinces[1].Cities[0].Counties[0].EnglishName; englishName != "Xiangzhou District" {
+		t.Errorf("Counties[0]: %s\n", englishName)
+	}
+	if englishName := table.ProvinceDistrictTable[440000].CityDistrictTable[440400].CountyDistrictTable[440402].EnglishName; englishName != "Xiangzhou District" {
+		t.Errorf("CountyDistrictTable[440402]: %s\n", englishName)
+	}
+	if englishName := table.Provinces[1].Cities[0].EnglishName; englishName != "Zhuhai Shi" {
+		t.Errorf("Cities[0]: %s\n", englishName)
+	}
+
+	if _, err := LoadEnglishNamesFromReader(ctx, strings.NewReader("440000\n")); err == nil {
+		t.Errorf("LoadEnglishNamesFromReader: expect error\n")
+	}
+}
@@ -0,0 +1,202 @@
+// Package httpapi 以 HTTP REST 接口提供行政区数据，数据来源于 district.Table ，不依赖数据库
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/eyjian/mooon-district/district"
+)
+
+// Options 服务的选项
+type Options struct {
+	Version  string                       // 数据版本，用作 ETag ，为空时由数据内容计算
+	MaxAge   int                          // Cache-Control 的 max-age 秒数，小于等于 0 时为 no-cache ，即每次都需通过 ETag 确认
+	Registry *district.SuccessionRegistry // 行政区代码继承关系，用于解析身份证号码中已撤销的行政区代码，可为 nil
+}
+
+// Handler 行政区数据的 HTTP 接口，接口如下（均为 GET ，返回 json）：
+// 1）/v1/provinces ：省级行政区列表；
+// 2）/v1/districts/{code}/children ：下一级行政区列表，如：/v1/districts/440400/children ；
+// 3）/v1/districts/{code} ：通过行政区代码取得行政区，如：/v1/districts/440402 ；
+// 4）/v1/districts?name= ：通过斜杠分隔的行政区名取得行政区，如：/v1/districts?name=广东/珠海/香洲 ；
+// 5）/v1/search?q=&limit= ：按中文、全拼或拼音首字母搜索行政区，如：/v1/search?q=zhuhai ；
+// 6）/v1/idcards/{number} ：解析身份证号码。
+// 找不到时返回 404 ，参数不合法时返回 400 ，错误的 json 为：{"error": "..."}
+type Handler struct {
+	table        *district.Table
+	query        *district.MemoryQuery
+	lookup       *district.Lookup
+	searcher     *district.Searcher
+	idCardParser *district.IdCardParser
+	etag         string
+	cacheControl string
+	mux          *http.ServeMux
+}
+
+// NewHandler 新建行政区数据的 HTTP 接口
+func NewHandler(table *district.Table, options Options) *Handler {
+	h := &Handler{
+		table:        table,
+		query:        district.NewMemoryQuery(table),
+		lookup:       district.NewLookup(table, options.Registry),
+		searcher:     district.NewSearcher(table),
+		idCardParser: district.NewIdCardParser(table, options.Registry),
+		mux:          http.NewServeMux(),
+	}
+
+	version := options.Version
+	if len(version) == 0 {
+		version = TableVersion(table)
+	}
+	h.etag = strconv.Quote(version)
+	if options.MaxAge > 0 {
+		h.cacheControl = fmt.Sprintf("public, max-age=%d", options.MaxAge)
+	} else {
+		h.cacheControl = "public, no-cache"
+	}
+
+	h.mux.HandleFunc("GET /v1/provinces", h.getProvinces)
+	h.mux.HandleFunc("GET /v1/districts/{code}/children", h.getChildren)
+	h.mux.HandleFunc("GET /v1/districts/{code}", h.getDistrict)
+	h.mux.HandleFunc("GET /v1/districts", h.getDistrictByName)
+	h.mux.HandleFunc("GET /v1/search", h.search)
+	h.mux.HandleFunc("GET /v1/idcards/{number}", h.getIdCard)
+	return h
+}
+
+// TableVersion 由行政区数据的内容计算数据版本，内容相同的版本相同，
+// 内容含接口返回的所有字段，如拼音、英文名、电话区号、邮政编码和类别
+func TableVersion(table *district.Table) string {
+	jsonBytes, _ := json.Marshal(table)
+	return district.Md5Sum(string(jsonBytes))[:16]
+}
+
+// ServeHTTP 实现 http.Handler ，数据不变，同一版本的响应也不变，
+// 成功的响应在 If-None-Match 含 ETag 时返回 304
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) getProvinces(w http.ResponseWriter, r *http.Request) {
+	provinces, _ := h.query.GetChildren(r.Context(), &district.Code{})
+	h.writeJson(w, r, provinces)
+}
+
+func (h *Handler) getChildren(w http.ResponseWriter, r *http.Request) {
+	result, ok := h.findCode(w, r)
+	if !ok {
+		return
+	}
+	children, _ := h.query.GetChildren(r.Context(), result.Code)
+	h.writeJson(w, r, children)
+}
+
+func (h *Handler) getDistrict(w http.ResponseWriter, r *http.Request) {
+	result, ok := h.findCode(w, r)
+	if !ok {
+		return
+	}
+	h.writeJson(w, r, result)
+}
+
+func (h *Handler) getDistrictByName(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(r.URL.Query().Get("name"))
+	if len(name) == 0 {
+		writeError(w, http.StatusBadRequest, "parameter name is not set")
+		return
+	}
+	code, _ := h.query.GetDistrictCode(r.Context(), district.ParseName(name))
+	if code == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("district %s not found", name))
+		return
+	}
+	h.writeJson(w, r, h.lookup.FindCode(code.Lowest()))
+}
+
+func (h *Handler) search(w http.ResponseWriter, r *http.Request) {
+	keyword := r.URL.Query().Get("q")
+	if len(strings.TrimSpace(keyword)) == 0 {
+		writeError(w, http.StatusBadRequest, "parameter q is not set")
+		return
+	}
+	limit := 10
+	if s := r.URL.Query().Get("limit"); len(s) > 0 {
+		var err error
+		if limit, err = strconv.Atoi(s); err != nil || limit < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid limit: %s", s))
+			return
+		}
+	}
+	h.writeJson(w, r, h.searcher.Search(keyword, limit))
+}
+
+func (h *Handler) getIdCard(w http.ResponseWriter, r *http.Request) {
+	idCard, err := h.idCardParser.Parse(r.PathValue("number"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	result := &district.LookupResult{}
+	if idCard.Code != nil {
+		result = h.lookup.FindCode(idCard.Code.Lowest())
+	}
+	result.IdCard = idCard
+	h.writeJson(w, r, result)
+}
+
+// findCode 取得路径中 code 对应的行政区，不合法或找不到时写错误响应并返回 false
+func (h *Handler) findCode(w http.ResponseWriter, r *http.Request) (*district.LookupResult, bool) {
+	s := r.PathValue("code")
+	code, err := strconv.ParseUint(s, 10, 32)
+	if err != nil || len(s) != 6 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid district code: %s", s))
+		return nil, false
+	}
+	result := h.lookup.FindCode(uint32(code))
+	if result == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("district %s not found", s))
+		return nil, false
+	}
+	return result, true
+}
+
+// writeJson 写成功的响应，带 ETag 和 Cache-Control ，If-None-Match 含 ETag 时返回 304
+func (h *Handler) writeJson(w http.ResponseWriter, r *http.Request, v any) {
+	jsonBytes, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("json marshal error: %s", err.Error()))
+		return
+	}
+	w.Header().Set("ETag", h.etag)
+	w.Header().Set("Cache-Control", h.cacheControl)
+	if matchEtag(r.Header.Get("If-None-Match"), h.etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, _ = w.Write(jsonBytes)
+}
+
+// matchEtag If-None-Match 是否含 etag ，按 RFC 9110 的弱比较，即忽略 W/ 前缀，值可为逗号分隔的列表或 *
+func matchEtag(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// writeError 写错误的响应，不缓存
+func writeError(w http.ResponseWriter, status int, message string) {
+	jsonBytes, _ := json.Marshal(map[string]string{"error": message})
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, _ = w.Write(jsonBytes)
+}
@@ -0,0 +1,144 @@
+// Package httpapi
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/eyjian/mooon-district/district"
+	"github.com/eyjian/mooon-district/district/dataset"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	table, err := dataset.ByYear(2023)
+	if err != nil {
+		t.Fatalf("ByYear error: %s\n", err.Error())
+	}
+	registry, err := dataset.Succession()
+	if err != nil {
+		t.Fatalf("Succession error: %s\n", err.Error())
+	}
+	return httptest.NewServer(NewHandler(table, Options{MaxAge: 3600, Registry: registry}))
+}
+
+func get(t *testing.T, server *httptest.Server, path string, v any) *http.Response {
+	resp, err := http.Get(server.URL + path)
+	if err != nil {
+		t.Fatalf("GET %s error: %s\n", path, err.Error())
+	}
+	defer resp.Body.Close()
+	if v != nil && resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("GET %s: decode error: %s\n", path, err.Error())
+		}
+	}
+	return resp
+}
+
+// go test -v -run="TestHandler$"
+func TestHandler(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	var provinces []district.DictDistrict
+	get(t, server, "/v1/provinces", &provinces)
+	if len(provinces) != 34 {
+		t.Errorf("provinces: %d\n", len(provinces))
+	}
+
+	var children []district.DictDistrict
+	get(t, server, "/v1/districts/440400/children", &children)
+	if len(children) != 3 || children[0].CountyName != "香洲区" {
+		t.Errorf("children of 440400: %v\n", children)
+	}
+
+	var result district.LookupResult
+	get(t, server, "/v1/districts/440402", &result)
+	if result.Path != "广东省/珠海市/香洲区" || result.Level != 3 {
+		t.Errorf("440402: %+v\n", result)
+	}
+
+	result = district.LookupResult{}
+	get(t, server, "/v1/districts?name="+url.QueryEscape("河南/济源"), &result)
+	if result.Code == nil || result.Code.Lowest() != 419001 || !result.ProvinceDirect {
+		t.Errorf("河南/济源: %+v\n", result)
+	}
+
+	var results []district.SearchResult
+	get(t, server, "/v1/search?q=zhuhai&limit=1", &results)
+	if len(results) != 1 || results[0].Path != "广东省/珠海市" {
+		t.Errorf("search zhuhai: %v\n", results)
+	}
+
+	result = district.LookupResult{}
+	get(t, server, "/v1/idcards/110108199003070016", &result)
+	if result.IdCard == nil || result.IdCard.Gender != "男" || result.Path != "北京市/海淀区" {
+		t.Errorf("idcard: %+v\n", result)
+	}
+
+	for path, status := range map[string]int{
+		"/v1/districts/440499":            http.StatusNotFound,
+		"/v1/districts/4404":              http.StatusBadRequest,
+		"/v1/districts?name=" + "火星":      http.StatusNotFound,
+		"/v1/search":                      http.StatusBadRequest,
+		"/v1/search?q=zhuhai&limit=x":     http.StatusBadRequest,
+		"/v1/idcards/110108199003070010":  http.StatusBadRequest,
+		"/v1/districts/440400/children/x": http.StatusNotFound,
+	} {
+		if resp := get(t, server, path, nil); resp.StatusCode != status {
+			t.Errorf("GET %s: %d, expect %d\n", path, resp.StatusCode, status)
+		}
+	}
+}
+
+// go test -v -run="TestHandlerCache$"
+func TestHandlerCache(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	resp := get(t, server, "/v1/provinces", nil)
+	etag := resp.Header.Get("ETag")
+	if len(etag) == 0 || resp.Header.Get("Cache-Control") != "public, max-age=3600" {
+		t.Fatalf("headers: %v\n", resp.Header)
+	}
+
+	// 只有成功的响应返回 304 ，If-None-Match 可为弱 ETag 或列表
+	for _, c := range []struct {
+		path        string
+		ifNoneMatch string
+		status      int
+	}{
+		{"/v1/districts/440402", etag, http.StatusNotModified},
+		{"/v1/districts/440402", "W/" + etag, http.StatusNotModified},
+		{"/v1/districts/440402", "\"other\", " + etag, http.StatusNotModified},
+		{"/v1/districts/440402", "\"other\"", http.StatusOK},
+		{"/v1/districts/999999", etag, http.StatusNotFound},
+		{"/v1/districts/4404", etag, http.StatusBadRequest},
+		{"/v1/unknown", etag, http.StatusNotFound},
+	} {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+c.path, nil)
+		req.Header.Set("If-None-Match", c.ifNoneMatch)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET error: %s\n", err.Error())
+		}
+		resp.Body.Close()
+		if resp.StatusCode != c.status {
+			t.Errorf("GET %s with If-None-Match %s: %d, expect %d\n", c.path, c.ifNoneMatch, resp.StatusCode, c.status)
+		}
+	}
+
+	// 数据不同时版本不同，包括拼音、英文名、电话区号和邮政编码等字段
+	table, _ := dataset.ByYear(2022)
+	version := TableVersion(table)
+	if "\""+version+"\"" == etag {
+		t.Errorf("TableVersion(2022): %s\n", version)
+	}
+	table.SetPostalInfo(map[uint32]district.PostalInfo{440402: {AreaCode: "0756", PostalCode: "519000"}})
+	if TableVersion(table) == version {
+		t.Errorf("TableVersion: not changed with postal codes\n")
+	}
+}
@@ -0,0 +1,166 @@
+// Package district
+package district
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// idCardWeights 18 位身份证号码前 17 位的加权因子（GB 11643）
+var idCardWeights = []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+
+// idCardCheckCodes 加权和模 11 后对应的校验码
+var idCardCheckCodes = "10X98765432"
+
+// idCardLocation 出生日期的时区，固定为北京时间（UTC+8），使解析结果不依赖主机的时区
+var idCardLocation = time.FixedZone("CST", 8*60*60)
+
+// IdCard 解析后的居民身份证号码
+type IdCard struct {
+	Number       string    `json:"number"`        // 18 位号码，15 位的会升级为 18 位
+	Legacy       bool      `json:"legacy"`        // 是否为 15 位的旧号码
+	DistrictCode uint32    `json:"district_code"` // 号码前 6 位的行政区代码
+	Birthday     time.Time `json:"birthday"`
+	Gender       string    `json:"gender"` // 男或女
+	Code         *Code     `json:"code,omitempty"`
+	Name         *Name     `json:"name,omitempty"`
+	Historical   bool      `json:"historical"` // 行政区代码已撤销，Code 和 Name 为继承关系解析出的现行行政区
+	Inexact      bool      `json:"inexact"`    // 行政区代码不存在，Code 和 Name 为其所属的市级或省级行政区
+}
+
+// IdCardParser 居民身份证号码解析器
+type IdCardParser struct {
+	table    *Table
+	registry *SuccessionRegistry
+}
+
+// NewIdCardParser 新建身份证号码解析器，
+// registry 为行政区代码继承关系，用于解析已撤销的行政区代码，可为 nil
+func NewIdCardParser(table *Table, registry *SuccessionRegistry) *IdCardParser {
+	return &IdCardParser{
+		table:    table,
+		registry: registry,
+	}
+}
+
+// Parse 解析身份证号码，校验位、出生日期不合法时返回错误，行政区代码找不到时 Code 和 Name 为 nil
+func (p *IdCardParser) Parse(number string) (*IdCard, error) {
+	idCard, err := ParseIdCard(number)
+	if err != nil {
+		return nil, err
+	}
+
+	// 现行行政区代码
+	idCard.Code, idCard.Name = p.table.FindCode(idCard.DistrictCode)
+	if idCard.Code != nil {
+		return idCard, nil
+	}
+
+	// 已撤销的行政区代码
+	if p.registry != nil {
+		codes, _ := p.registry.ResolveCurrent(idCard.DistrictCode)
+		for _, code := range codes {
+			idCard.Code, idCard.Name = p.table.FindCode(code)
+			if idCard.Code != nil {
+				idCard.Historical = true
+				return idCard, nil
+			}
+		}
+	}
+
+	// 退而取所属的市级或省级行政区
+	for _, code := range []uint32{getCityDistrictCode(idCard.DistrictCode), getProvinceDistrictCode(idCard.DistrictCode)} {
+		idCard.Code, idCard.Name = p.table.FindCode(code)
+		if idCard.Code != nil {
+			idCard.Inexact = true
+			return idCard, nil
+		}
+	}
+	return idCard, nil
+}
+
+// ParseIdCard 解析身份证号码，只校验号码本身，不解析行政区
+func ParseIdCard(number string) (*IdCard, error) {
+	number = strings.ToUpper(strings.TrimSpace(number))
+	idCard := &IdCard{}
+
+	if len(number) == 15 {
+		upgraded, err := UpgradeIdCard(number)
+		if err != nil {
+			return nil, err
+		}
+		number = upgraded
+		idCard.Legacy = true
+	} else if len(number) != 18 {
+		return nil, fmt.Errorf("invalid id card length: %d", len(number))
+	} else {
+		checkCode, err := IdCardCheckCode(number[:17])
+		if err != nil {
+			return nil, err
+		}
+		if checkCode != number[17] {
+			return nil, fmt.Errorf("invalid id card check code: %c, expected %c", number[17], checkCode)
+		}
+	}
+
+	birthday, err := time.ParseInLocation("20060102", number[6:14], idCardLocation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id card birthday: %s", number[6:14])
+	}
+	if birthday.After(time.Now()) {
+		return nil, fmt.Errorf("invalid id card birthday: %s is in the future", number[6:14])
+	}
+	districtCode, _ := strconv.ParseUint(number[:6], 10, 32)
+
+	idCard.Number = number
+	idCard.DistrictCode = uint32(districtCode)
+	idCard.Birthday = birthday
+	if (number[16]-'0')%2 == 1 {
+		idCard.Gender = "男"
+	} else {
+		idCard.Gender = "女"
+	}
+	return idCard, nil
+}
+
+// UpgradeIdCard 将 15 位身份证号码升级为 18 位：出生年份补上 19 ，末尾加上校验位
+func UpgradeIdCard(number string) (string, error) {
+	if len(number) != 15 {
+		return "", fmt.Errorf("invalid legacy id card length: %d", len(number))
+	}
+
+	first17 := number[:6] + "19" + number[6:]
+	checkCode, err := IdCardCheckCode(first17)
+	if err != nil {
+		return "", err
+	}
+	return first17 + string(checkCode), nil
+}
+
+// IdCardCheckCode 计算 18 位身份证号码的校验位，参数为号码的前 17 位
+func IdCardCheckCode(first17 string) (byte, error) {
+	if len(first17) != 17 {
+		return 0, fmt.Errorf("invalid id card length: %d", len(first17))
+	}
+
+	sum := 0
+	for i := 0; i < 17; i++ {
+		if first17[i] < '0' || first17[i] > '9' {
+			return 0, fmt.Errorf("invalid id card digit: %c", first17[i])
+		}
+		sum += int(first17[i]-'0') * idCardWeights[i]
+	}
+	return idCardCheckCodes[sum%11], nil
+}
+
+// Age 计算在指定时间的周岁，按北京时间的日期计算
+func (c *IdCard) Age(now time.Time) int {
+	now = now.In(idCardLocation)
+	age := now.Year() - c.Birthday.Year()
+	if now.Month() < c.Birthday.Month() || (now.Month() == c.Birthday.Month() && now.Day() < c.Birthday.Day()) {
+		age--
+	}
+	return age
+}
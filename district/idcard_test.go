@@ -0,0 +1,90 @@
+// Package district
+package district
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// go test -v -run="TestParseIdCard$"
+func TestParseIdCard(t *testing.T) {
+	idCard, err := ParseIdCard("11010519491231002x")
+	if err != nil {
+		t.Fatalf("ParseIdCard error: %s\n", err.Error())
+	}
+	if idCard.Number != "11010519491231002X" || idCard.DistrictCode != 110105 || idCard.Gender != "女" ||
+		idCard.Birthday.Format("2006-01-02") != "1949-12-31" {
+		t.Errorf("ParseIdCard: %+v\n", *idCard)
+	}
+	if age := idCard.Age(time.Date(2023, 12, 30, 0, 0, 0, 0, time.Local)); age != 73 {
+		t.Errorf("Age: %d\n", age)
+	}
+
+	// 15 位旧号码
+	idCard, err = ParseIdCard("110105491231002")
+	if err != nil || idCard.Number != "11010519491231002X" || !idCard.Legacy {
+		t.Errorf("ParseIdCard(15): %v %v\n", idCard, err)
+	}
+
+	for _, number := range []string{"110105194912310021", "11010519491331002X", "1101051949123100", "1101051949123100AX"} {
+		if _, err = ParseIdCard(number); err == nil {
+			t.Errorf("ParseIdCard(%s): expect error\n", number)
+		}
+	}
+}
+
+// go test -v -run="TestIdCardBirthdayLocation$"
+func TestIdCardBirthdayLocation(t *testing.T) {
+	// 出生日期按北京时间解析，不受主机时区影响
+	local := time.Local
+	defer func() { time.Local = local }()
+	for _, offset := range []int{-12, 0, 8, 14} {
+		time.Local = time.FixedZone("", offset*60*60)
+		idCard, err := ParseIdCard("11010519491231002X")
+		if err != nil {
+			t.Fatalf("ParseIdCard error: %s\n", err.Error())
+		}
+		if idCard.Birthday.Unix() != -631267200 {
+			t.Errorf("Birthday(%d): %v\n", offset, idCard.Birthday)
+		}
+		// UTC 2023-12-30 16:30 已是北京时间 2023-12-31
+		if age := idCard.Age(time.Date(2023, 12, 30, 16, 30, 0, 0, time.UTC)); age != 74 {
+			t.Errorf("Age(%d): %d\n", offset, age)
+		}
+	}
+}
+
+// go test -v -run="TestIdCardParser$"
+func TestIdCardParser(t *testing.T) {
+	ctx := context.Background()
+	table, _ := LoadDistrictFromReader(ctx, strings.NewReader(testNewDistrictData))
+	registry := NewSuccessionRegistry()
+	registry.Add(Succession{OldCode: 540422, NewCode: 540481, Kind: SuccessionRecode})
+	parser := NewIdCardParser(table, registry)
+
+	// 现行代码
+	number := "540481199001011234"
+	checkCode, _ := IdCardCheckCode(number[:17])
+	idCard, err := parser.Parse(number[:17] + string(checkCode))
+	if err != nil || idCard.Name == nil || idCard.Name.CountyName != "米林市" || idCard.Historical {
+		t.Errorf("Parse(540481): %v %v\n", idCard, err)
+	}
+
+	// 已撤销的代码
+	number = "540422199001011234"
+	checkCode, _ = IdCardCheckCode(number[:17])
+	idCard, err = parser.Parse(number[:17] + string(checkCode))
+	if err != nil || idCard.Code == nil || idCard.Code.CountyCode != 540481 || !idCard.Historical {
+		t.Errorf("Parse(540422): %v %v\n", idCard, err)
+	}
+
+	// 不存在的代码退而取市级行政区
+	number = "540499199001011234"
+	checkCode, _ = IdCardCheckCode(number[:17])
+	idCard, err = parser.Parse(number[:17] + string(checkCode))
+	if err != nil || idCard.Name == nil || idCard.Name.CityName != "林芝市" || !idCard.Inexact {
+		t.Errorf("Parse(540499): %v %v\n", idCard, err)
+	}
+}
@@ -0,0 +1,71 @@
+// Package district
+package district
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DistrictKind 行政区类别
+type DistrictKind string
+
+const (
+	KindAdministrative DistrictKind = "administrative" // 行政区
+	KindFunctional     DistrictKind = "functional"     // 功能区，如：130471 经开区、133100 雄安新区
+	KindSummary        DistrictKind = "summary"        // 省（自治区）直辖县级行政区划汇总码（第三、四位为 90）下的，如：419001 济源市
+	KindSynthetic      DistrictKind = "synthetic"      // 无编码行政区的合成代码，非官方代码，如：西沙区的 460399
+)
+
+// functionalSuffixes 功能区名的后缀，只用于识别市级的功能区，县级的功能区以代码识别
+var functionalSuffixes = []string{"新区", "开发区", "管理区", "试验区", "示范区"}
+
+// LoadOptions 加载行政区数据的选项，零值为全部加载
+type LoadOptions struct {
+	ExcludeFunctional bool // 不含功能区（官方行政区划不含功能区）
+	ExcludeSummary    bool // 不含省（自治区）直辖县级行政区划汇总码下的
+
+	// 无编码的行（如：,西沙区）缺省被跳过，UncodedCodes 按名称为其指定代码（见 LoadUncodedCodes），
+	// SyntheticCodes 为 true 时为不在 UncodedCodes 中的分配合成代码（Kind 为 KindSynthetic）
+	UncodedCodes   map[string]uint32
+	SyntheticCodes bool
+}
+
+// ClassifyDistrict 取得行政区的类别：
+// 1）县级代码第五、六位为 71 至 80 的，为功能区，如：130471 经开区；
+// 2）市级名称以“新区”、“开发区”等结尾的，为功能区，如：133100 雄安新区；
+// 3）代码第三、四位为 90 的，为省（自治区）直辖县级行政区划汇总码下的，如：419001 济源市；
+// 4）其它为行政区。
+func ClassifyDistrict(code uint32, name string) DistrictKind {
+	if IsCountyDistrictCode(code) && code%100 >= 71 && code%100 <= 80 {
+		return KindFunctional
+	}
+	if IsCityDistrictCode(code) && !IsMunicipalityCode(code) {
+		for _, suffix := range functionalSuffixes {
+			if strings.HasSuffix(name, suffix) {
+				return KindFunctional
+			}
+		}
+	}
+	if (code/100)%100 == 90 {
+		return KindSummary
+	}
+	return KindAdministrative
+}
+
+// excluded 是否被选项排除
+func (o *LoadOptions) excluded(kind DistrictKind) bool {
+	return (o.ExcludeFunctional && kind == KindFunctional) || (o.ExcludeSummary && kind == KindSummary)
+}
+
+// lineDistrictCode 取得行首的 6 位行政区代码（统计用区划代码取前 6 位），不是代码的返回 0
+func lineDistrictCode(line string) uint32 {
+	code := strings.TrimSpace(strings.SplitN(line, ",", 2)[0])
+	if len(code) > 6 {
+		code = code[:6]
+	}
+	n, err := strconv.ParseUint(code, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(n)
+}
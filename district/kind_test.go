@@ -0,0 +1,84 @@
+// Package district
+package district
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// go test -v -run="TestClassifyDistrict$"
+func TestClassifyDistrict(t *testing.T) {
+	cases := []struct {
+		code uint32
+		name string
+		kind DistrictKind
+	}{
+		{440000, "广东省", KindAdministrative},
+		{440402, "香洲区", KindAdministrative},
+		{310115, "浦东新区", KindAdministrative},
+		{130471, "经开区", KindFunctional},
+		{130271, "芦台经济开发区", KindFunctional},
+		{133100, "雄安新区", KindFunctional},
+		{419001, "济源市", KindSummary},
+		{429021, "神农架林区", KindSummary},
+	}
+	for _, c := range cases {
+		if kind := ClassifyDistrict(c.code, c.name); kind != c.kind {
+			t.Errorf("ClassifyDistrict(%d, %s): %s, expect %s\n", c.code, c.name, kind, c.kind)
+		}
+	}
+}
+
+// go test -v -run="TestLoadDistrictWithOptions$"
+func TestLoadDistrictWithOptions(t *testing.T) {
+	ctx := context.Background()
+
+	table, err := LoadDistrict(ctx, "../district-2023.csv")
+	if err != nil {
+		t.Fatalf("LoadDistrict error: %s\n", err.Error())
+	}
+	hebei := table.ProvinceDistrictTable[130000]
+	if city, ok := hebei.CityDistrictTable[133100]; !ok || city.Kind != KindFunctional {
+		t.Errorf("133100: %v\n", city)
+	}
+	if county, ok := hebei.CityDistrictTable[130400].CountyDistrictTable[130471]; !ok || county.Kind != KindFunctional {
+		t.Errorf("130471: %v\n", county)
+	}
+	if city := table.ProvinceDistrictTable[410000].CityDistrictTable[419001]; city.Kind != KindSummary {
+		t.Errorf("419001: %s\n", city.Kind)
+	}
+
+	table, err = LoadDistrictWithOptions(ctx, "../district-2023.csv", LoadOptions{ExcludeFunctional: true})
+	if err != nil {
+		t.Fatalf("LoadDistrictWithOptions error: %s\n", err.Error())
+	}
+	hebei = table.ProvinceDistrictTable[130000]
+	if _, ok := hebei.CityDistrictTable[133100]; ok {
+		t.Errorf("ExcludeFunctional: 133100 found\n")
+	}
+	if _, ok := hebei.CityDistrictTable[130400].CountyDistrictTable[130471]; ok {
+		t.Errorf("ExcludeFunctional: 130471 found\n")
+	}
+	if _, ok := table.ProvinceDistrictTable[410000].CityDistrictTable[419001]; !ok {
+		t.Errorf("ExcludeFunctional: 419001 not found\n")
+	}
+
+	// 被排除的行政区的下级也被排除
+	data := "440000,广东省\n449900,某某新区\n449901,某某区\n440400,珠海市"
+	table, err = LoadDistrictFromReaderWithOptions(ctx, strings.NewReader(data), LoadOptions{ExcludeFunctional: true})
+	if err != nil {
+		t.Fatalf("LoadDistrictFromReaderWithOptions error: %s\n", err.Error())
+	}
+	if cities := table.ProvinceDistrictTable[440000].CityDistrictTable; len(cities) != 1 {
+		t.Errorf("ExcludeFunctional: %v\n", cities)
+	}
+
+	table, err = LoadDistrictWithOptions(ctx, "../district-2023.csv", LoadOptions{ExcludeSummary: true})
+	if err != nil {
+		t.Fatalf("LoadDistrictWithOptions error: %s\n", err.Error())
+	}
+	if _, ok := table.ProvinceDistrictTable[410000].CityDistrictTable[419001]; ok {
+		t.Errorf("ExcludeSummary: 419001 found\n")
+	}
+}
@@ -0,0 +1,146 @@
+// Package district
+package district
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// LookupResult 查询行政区的结果
+type LookupResult struct {
+	Code            *Code   `json:"code,omitempty"`
+	Name            *Name   `json:"name,omitempty"`
+	Path            string  `json:"path,omitempty"`    // 完整路径，如：广东省/珠海市/香洲区
+	Level           uint32  `json:"level,omitempty"`   // 行政区级别，直辖市的区县同 Table 一样为 2
+	Municipality    bool    `json:"municipality"`      // 直辖市或直辖市的区县
+	CountyLevelCity bool    `json:"county_level_city"` // 县级市，如：昆山市、济源市
+	ProvinceDirect  bool    `json:"province_direct"`   // 省直辖县级行政区，如：济源市
+	IdCard          *IdCard `json:"id_card,omitempty"` // 输入为身份证号码时的解析结果
+}
+
+// Lookup 行政区查询器，可通过行政区代码、斜杠分隔的行政区名或身份证号码查询行政区
+type Lookup struct {
+	table        *Table
+	query        *MemoryQuery
+	searcher     *Searcher
+	idCardParser *IdCardParser
+}
+
+// NewLookup 新建行政区查询器，
+// registry 为行政区代码继承关系，用于解析身份证号码中已撤销的行政区代码，可为 nil
+func NewLookup(table *Table, registry *SuccessionRegistry) *Lookup {
+	return &Lookup{
+		table:        table,
+		query:        NewMemoryQuery(table),
+		searcher:     NewSearcher(table),
+		idCardParser: NewIdCardParser(table, registry),
+	}
+}
+
+// Lookup 查询行政区，input 可为：
+// 1）行政区代码，2 位和 4 位的补足为 6 位，如：440402、4404、44；
+// 2）斜杠分隔的行政区名，可为简名，如：河南省/济源市、广东/珠海/香洲，唯一的单个行政区名也可，如：香洲区；
+// 3）15 位或 18 位的身份证号码，结果含 IdCard ，号码中的行政区找不到时 Code 为 nil 。
+// 返回值：
+// 1）成功返回非 nil 的 LookupResult，同时 error 值为 nil ；
+// 2）不存在返回 nil 的 LookupResult，同时 error 值为 nil ；
+// 3）身份证号码不合法时返回 error 。
+func (l *Lookup) Lookup(input string) (*LookupResult, error) {
+	input = strings.TrimSpace(input)
+	if len(input) == 0 {
+		return nil, nil
+	}
+
+	if isIdCardNumber(input) {
+		idCard, err := l.idCardParser.Parse(input)
+		if err != nil {
+			return nil, err
+		}
+		result := &LookupResult{}
+		if idCard.Code != nil {
+			result = l.FindCode(idCard.Code.Lowest())
+		}
+		result.IdCard = idCard
+		return result, nil
+	}
+
+	if isDigits(input) {
+		switch len(input) {
+		case 2:
+			input += "0000"
+		case 4:
+			input += "00"
+		case 6:
+		default:
+			return nil, nil
+		}
+		code, _ := strconv.ParseUint(input, 10, 32)
+		return l.FindCode(uint32(code)), nil
+	}
+
+	code, _ := l.query.GetDistrictCode(context.Background(), ParseName(input))
+	if code != nil {
+		return l.FindCode(code.Lowest()), nil
+	}
+
+	// 单个的行政区名，如：香洲区、海淀，同名的（如：朝阳区）不确定
+	if !strings.Contains(input, "/") {
+		var found *SearchResult
+		for _, result := range l.searcher.Search(input, 0) {
+			if result.Score < 0.95 {
+				break
+			}
+			if found != nil {
+				return nil, nil
+			}
+			found = &result
+		}
+		if found != nil {
+			return l.FindCode(found.Code.Lowest()), nil
+		}
+	}
+	return nil, nil
+}
+
+// FindCode 通过 6 位行政区代码查询行政区，不存在时返回 nil
+func (l *Lookup) FindCode(code uint32) *LookupResult {
+	c, n := l.table.FindCode(code)
+	if c == nil {
+		return nil
+	}
+
+	result := &LookupResult{
+		Code:         c,
+		Name:         n,
+		Path:         n.ProvinceName,
+		Municipality: IsMunicipalityCode(code),
+	}
+	provinceDistrict := l.table.ProvinceDistrictTable[c.ProvinceCode]
+	result.Level = provinceDistrict.Level
+	if c.CityCode != 0 {
+		cityDistrict := provinceDistrict.CityDistrictTable[c.CityCode]
+		result.Path += "/" + n.CityName
+		result.Level = cityDistrict.Level
+		result.CountyLevelCity = cityDistrict.CountyCity
+		result.ProvinceDirect = cityDistrict.CountyCity
+	}
+	if c.CountyCode != 0 {
+		countyDistrict := provinceDistrict.CityDistrictTable[c.CityCode].CountyDistrictTable[c.CountyCode]
+		result.Path += "/" + n.CountyName
+		result.Level = countyDistrict.Level
+		result.CountyLevelCity = strings.HasSuffix(countyDistrict.Name, "市")
+	}
+	return result
+}
+
+// isIdCardNumber 是否为身份证号码，即 15 位数字，或者 17 位数字加数字或 X 的校验码
+func isIdCardNumber(s string) bool {
+	switch len(s) {
+	case 15:
+		return isDigits(s)
+	case 18:
+		return isDigits(s[:17]) && (isDigits(s[17:]) || s[17] == 'X' || s[17] == 'x')
+	}
+	return false
+}
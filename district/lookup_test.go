@@ -0,0 +1,58 @@
+// Package district
+package district
+
+import (
+	"context"
+	"testing"
+)
+
+// go test -v -run="TestLookup$"
+func TestLookup(t *testing.T) {
+	table, err := LoadDistrict(context.Background(), "../district-2023.csv")
+	if err != nil {
+		t.Fatalf("LoadDistrict error: %s\n", err.Error())
+	}
+	lookup := NewLookup(table, nil)
+
+	cases := []struct {
+		input           string
+		path            string
+		level           uint32
+		municipality    bool
+		countyLevelCity bool
+		provinceDirect  bool
+	}{
+		{"440402", "广东省/珠海市/香洲区", 3, false, false, false},
+		{"4404", "广东省/珠海市", 2, false, false, false},
+		{"44", "广东省", 1, false, false, false},
+		{"河南省/济源市", "河南省/济源市", 3, false, true, true},
+		{"江苏/苏州/昆山", "江苏省/苏州市/昆山市", 3, false, true, false},
+		{"北京/海淀", "北京市/海淀区", 2, true, false, false},
+		{"香洲区", "广东省/珠海市/香洲区", 3, false, false, false},
+		{"110108199003070016", "北京市/海淀区", 2, true, false, false},
+	}
+	for _, c := range cases {
+		result, err := lookup.Lookup(c.input)
+		if err != nil {
+			t.Errorf("Lookup(%s) error: %s\n", c.input, err.Error())
+			continue
+		}
+		if result == nil {
+			t.Errorf("Lookup(%s): not found\n", c.input)
+			continue
+		}
+		if result.Path != c.path || result.Level != c.level || result.Municipality != c.municipality ||
+			result.CountyLevelCity != c.countyLevelCity || result.ProvinceDirect != c.provinceDirect {
+			t.Errorf("Lookup(%s): %+v\n", c.input, *result)
+		}
+	}
+
+	for _, input := range []string{"440499", "火星", "朝阳区", ""} {
+		if result, err := lookup.Lookup(input); result != nil || err != nil {
+			t.Errorf("Lookup(%s): %v, %v\n", input, result, err)
+		}
+	}
+	if _, err := lookup.Lookup("110108199003070010"); err == nil {
+		t.Errorf("Lookup(invalid id card): expect error\n")
+	}
+}
@@ -0,0 +1,179 @@
+// Package district
+package district
+
+import (
+	"context"
+)
+
+// MemoryQuery 基于内存的行政区查询器，不依赖数据库，
+// 数据来源于 LoadDistrict 返回的 Table ，查询语义同 Query 保持一致
+type MemoryQuery struct {
+	rows        []DictDistrict
+	codeTable   map[Name]Code
+	nameTable   map[Code]Name
+	countyCount map[string]int
+	children    map[Code][]DictDistrict
+	normalizer  *Normalizer
+}
+
+// NewMemoryQuery 新建基于内存的查询对象
+func NewMemoryQuery(table *Table) *MemoryQuery {
+	rows := table.Rows()
+	q := &MemoryQuery{
+		rows:        rows,
+		codeTable:   make(map[Name]Code, len(rows)),
+		nameTable:   make(map[Code]Name, len(rows)),
+		countyCount: make(map[string]int),
+		children:    make(map[Code][]DictDistrict),
+		normalizer:  NewNormalizer(rows),
+	}
+
+	for _, row := range rows {
+		name := Name{
+			ProvinceName: row.ProvinceName,
+			CityName:     row.CityName,
+			CountyName:   row.CountyName,
+		}
+		code := Code{
+			ProvinceCode: row.ProvinceCode,
+			CityCode:     row.CityCode,
+			CountyCode:   row.CountyCode,
+		}
+
+		// 同名时以先出现的为准，同数据库的 First 行为保持一致
+		if _, ok := q.codeTable[name]; !ok {
+			q.codeTable[name] = code
+		}
+		q.nameTable[code] = name
+		q.countyCount[countyCountKey(row.ProvinceName, row.CityName)]++
+
+		// 按上一级行政区代码归类
+		parent := Code{}
+		if row.CountyCode != 0 {
+			parent = Code{ProvinceCode: row.ProvinceCode, CityCode: row.CityCode}
+		} else if row.CityCode != 0 {
+			parent = Code{ProvinceCode: row.ProvinceCode}
+		}
+		q.children[parent] = append(q.children[parent], row)
+	}
+
+	return q
+}
+
+// Rows 将行政区表展开为同 t_dict_district 表一致的行，
+// 行的顺序和 GenerateSql 生成的 INSERT 语句的顺序相同
+func (t *Table) Rows() []DictDistrict {
+	rows := make([]DictDistrict, 0)
+
+	for _, provinceDistrict := range t.Provinces {
+		// 省/自治区/直辖市
+		rows = append(rows, DictDistrict{
+			ProvinceCode: provinceDistrict.Code,
+			Level:        provinceDistrict.Level,
+			ProvinceName: provinceDistrict.Name,
+		})
+
+		for _, cityDistrict := range provinceDistrict.Cities {
+			// 市/州/盟
+			rows = append(rows, DictDistrict{
+				ProvinceCode: provinceDistrict.Code,
+				CityCode:     cityDistrict.Code,
+				Level:        cityDistrict.Level,
+				ProvinceName: provinceDistrict.Name,
+				CityName:     cityDistrict.Name,
+			})
+
+			for _, countyDistrict := range cityDistrict.Counties {
+				// 县/县级市/旗
+				rows = append(rows, DictDistrict{
+					ProvinceCode: provinceDistrict.Code,
+					CityCode:     cityDistrict.Code,
+					CountyCode:   countyDistrict.Code,
+					Level:        countyDistrict.Level,
+					ProvinceName: provinceDistrict.Name,
+					CityName:     cityDistrict.Name,
+					CountyName:   countyDistrict.Name,
+				})
+			}
+		}
+	}
+
+	return rows
+}
+
+// FindCode 通过 6 位行政区代码取得完整的行政区代码和行政区名，不存在时都返回 nil
+func (t *Table) FindCode(code uint32) (*Code, *Name) {
+	provinceCode := getProvinceDistrictCode(code)
+	provinceDistrict, ok := t.ProvinceDistrictTable[provinceCode]
+	if !ok {
+		return nil, nil
+	}
+	if code == provinceCode {
+		return &Code{ProvinceCode: provinceCode}, &Name{ProvinceName: provinceDistrict.Name}
+	}
+
+	// 市/州/盟，以及省直辖县级市和直辖市的区县
+	if cityDistrict, ok := provinceDistrict.CityDistrictTable[code]; ok {
+		return &Code{ProvinceCode: provinceCode, CityCode: code},
+			&Name{ProvinceName: provinceDistrict.Name, CityName: cityDistrict.Name}
+	}
+
+	// 县/县级市/旗
+	cityCode := getCityDistrictCode(code)
+	if cityDistrict, ok := provinceDistrict.CityDistrictTable[cityCode]; ok {
+		if countyDistrict, ok := cityDistrict.CountyDistrictTable[code]; ok {
+			return &Code{ProvinceCode: provinceCode, CityCode: cityCode, CountyCode: code},
+				&Name{ProvinceName: provinceDistrict.Name, CityName: cityDistrict.Name, CountyName: countyDistrict.Name}
+		}
+	}
+	return nil, nil
+}
+
+// GetDistrictCode 通过行政区名取得行政区代码，行政区名可为简名，如：广东/珠海/香洲
+// 返回值：
+// 1）成功返回非 nil 的 DistrictCode，同时 error 值为 nil ；
+// 2）不存在返回 nil 的 DistrictCode，同时 error 值为 nil 。
+func (q *MemoryQuery) GetDistrictCode(ctx context.Context, name *Name) (*Code, error) {
+	code, ok := q.codeTable[*name]
+	if !ok {
+		// 简名或后缀不同的，如：广东/珠海/香洲
+		normalizedName := q.normalizer.Normalize(name)
+		if normalizedName == nil {
+			return nil, nil // 不存在
+		}
+		if code, ok = q.codeTable[*normalizedName]; !ok {
+			return nil, nil // 不存在
+		}
+	}
+	return &code, nil
+}
+
+// GetDistrictName 通过行政区代码取得行政区名
+// 返回值：
+// 1）成功返回非 nil 的 DistrictName，同时 error 值为 nil ；
+// 2）不存在返回 nil 的 DistrictName，同时 error 值为 nil 。
+func (q *MemoryQuery) GetDistrictName(ctx context.Context, code *Code) (*Name, error) {
+	name, ok := q.nameTable[*code]
+	if !ok {
+		return nil, nil // 不存在
+	}
+	return &name, nil
+}
+
+// GetCountyCount 取得县/县级市/旗数，像东莞市没有，
+// 同 Query 一样计数包含市级行政区自身所在的行
+func (q *MemoryQuery) GetCountyCount(ctx context.Context, provinceName, cityName string) (int, error) {
+	return q.countyCount[countyCountKey(provinceName, cityName)], nil
+}
+
+// GetChildren 取得下一级行政区列表
+func (q *MemoryQuery) GetChildren(ctx context.Context, code *Code) ([]DictDistrict, error) {
+	children := q.children[*code]
+	results := make([]DictDistrict, len(children))
+	copy(results, children)
+	return results, nil
+}
+
+func countyCountKey(provinceName, cityName string) string {
+	return "CountyCount:" + provinceName + ":" + cityName
+}
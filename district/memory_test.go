@@ -0,0 +1,78 @@
+// Package district
+package district
+
+import (
+	"context"
+	"testing"
+)
+
+// go test -v -run="TestMemoryQuery$"
+func TestMemoryQuery(t *testing.T) {
+	ctx := context.Background()
+	table, err := LoadDistrict(ctx, "../district-2023.csv")
+	if err != nil {
+		t.Fatalf("LoadDistrict error: %s\n", err.Error())
+	}
+	query := NewMemoryQuery(table)
+
+	name := &Name{
+		ProvinceName: "广东省",
+		CityName:     "珠海市",
+		CountyName:   "香洲区",
+	}
+	code, _ := query.GetDistrictCode(ctx, name)
+	if code == nil || code.ProvinceCode != 440000 || code.CityCode != 440400 || code.CountyCode != 440402 {
+		t.Errorf("GetDistrictCode(%v): %v\n", *name, code)
+	}
+
+	name.CountyName = "香洲区X"
+	if code, _ = query.GetDistrictCode(ctx, name); code != nil {
+		t.Errorf("GetDistrictCode(%v): %v, expect not found\n", *name, *code)
+	}
+
+	// 省直辖县级市
+	name.ProvinceName = "河南省"
+	name.CityName = "济源市"
+	name.CountyName = ""
+	code, _ = query.GetDistrictCode(ctx, name)
+	if code == nil || code.CityCode != 419001 {
+		t.Errorf("GetDistrictCode(%v): %v\n", *name, code)
+	}
+
+	// 直辖市的区县
+	code = &Code{ProvinceCode: 110000, CityCode: 110108}
+	result, _ := query.GetDistrictName(ctx, code)
+	if result == nil || result.ProvinceName != "北京市" || result.CityName != "海淀区" {
+		t.Errorf("GetDistrictName(%v): %v\n", *code, result)
+	}
+
+	code.CityCode = 110100
+	if result, _ = query.GetDistrictName(ctx, code); result != nil {
+		t.Errorf("GetDistrictName(%v): %v, expect not found\n", *code, *result)
+	}
+
+	// 同 Query 一样，计数包含市级行政区自身
+	count, _ := query.GetCountyCount(ctx, "广东省", "东莞市")
+	if count != 1 {
+		t.Errorf("GetCountyCount(广东省,东莞市): %d\n", count)
+	}
+	count, _ = query.GetCountyCount(ctx, "广东省", "深圳市")
+	if count <= 1 {
+		t.Errorf("GetCountyCount(广东省,深圳市): %d\n", count)
+	}
+
+	// 通过 Resolver 取得下一级行政区
+	var resolver Resolver = query
+	children, _ := resolver.GetChildren(ctx, &Code{})
+	if len(children) != 34 {
+		t.Errorf("GetChildren(): %d provinces\n", len(children))
+	}
+	children, _ = resolver.GetChildren(ctx, &Code{ProvinceCode: 440000, CityCode: 440400})
+	if len(children) != 3 {
+		t.Errorf("GetChildren(440000,440400): %d counties\n", len(children))
+	}
+	children, _ = resolver.GetChildren(ctx, &Code{ProvinceCode: 440000, CityCode: 440400, CountyCode: 440402})
+	if len(children) != 0 {
+		t.Errorf("GetChildren(440000,440400,440402): %d\n", len(children))
+	}
+}
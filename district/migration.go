@@ -0,0 +1,307 @@
+// Package district
+package district
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// rowKey t_dict_district 表的主键，不含乡级和村级时乡级和村级代码为 0
+type rowKey struct {
+	ProvinceCode uint32
+	CityCode     uint32
+	CountyCode   uint32
+	TownshipCode uint64
+	VillageCode  uint64
+}
+
+// migrationRow t_dict_district 表的一行，含 GenerateSql 支持的可选列，未启用的列值为空
+type migrationRow struct {
+	DictDistrict
+	Pinyin         string
+	PinyinInitials string
+	EnglishName    string
+	AreaCode       string
+	PostalCode     string
+	TownshipCode   uint64
+	TownshipName   string
+	VillageCode    uint64
+	VillageName    string
+	Synthetic      bool // 县级的代码为合成代码
+}
+
+// migrationColumns 增量 SQL 的列，和 GenerateSql 生成的表结构一致
+type migrationColumns struct {
+	withPinyin   bool
+	withPostal   bool
+	withTownship bool
+	withVillage  bool
+}
+
+// MigrationSql 比较新旧两份行政区数据，生成将 t_dict_district 表从旧数据升级到新数据的增量 SQL ，
+// 包含 DELETE 、UPDATE 和 INSERT 语句，整体放在一个事务中
+func MigrationSql(oldTable, newTable *Table, tableName string) string {
+	return MigrationSqlWithOptions(oldTable, newTable, SqlOptions{TableName: tableName})
+}
+
+// MigrationSqlWithOptions 同 MigrationSql ，表结构为 GenerateSqlWithOptions 以相同选项生成的，
+// 即含拼音、电话区号和邮政编码等可选列，新旧数据任一含乡级（或村级）时也含乡级（或村级）的列和行，
+// options.WithIgnore 不起作用
+func MigrationSqlWithOptions(oldTable, newTable *Table, options SqlOptions) string {
+	tableName := options.TableName
+	if len(tableName) == 0 {
+		tableName = "t_dict_district"
+	}
+	columns := migrationColumns{
+		withPinyin:   options.WithPinyin,
+		withPostal:   options.WithPostal,
+		withTownship: oldTable.HasTownships() || newTable.HasTownships(),
+		withVillage:  oldTable.HasVillages() || newTable.HasVillages(),
+	}
+
+	oldRows := indexRows(migrationRows(oldTable, columns))
+	newRows := indexRows(migrationRows(newTable, columns))
+	deleted := make([]migrationRow, 0)
+	updated := make([]migrationRow, 0)
+	inserted := make([]migrationRow, 0)
+
+	for key, row := range oldRows {
+		if _, ok := newRows[key]; !ok {
+			deleted = append(deleted, row)
+		}
+	}
+	for key, row := range newRows {
+		oldRow, ok := oldRows[key]
+		if !ok {
+			inserted = append(inserted, row)
+		} else if oldRow.Synthetic != row.Synthetic {
+			// 合成代码和同代码的正式行政区为不同的行政区，先删除再插入
+			deleted = append(deleted, oldRow)
+			inserted = append(inserted, row)
+		} else if oldRow != row {
+			// 上级行政区更名时，下级行政区所在行的名称也会跟着变化
+			updated = append(updated, row)
+		}
+	}
+	sortRows(deleted)
+	sortRows(updated)
+	sortRows(inserted)
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("-- DELETE: %d, UPDATE: %d, INSERT: %d\n", len(deleted), len(updated), len(inserted)))
+	builder.WriteString("START TRANSACTION;\n")
+	for _, row := range deleted {
+		builder.WriteString(fmt.Sprintf("DELETE FROM %s WHERE %s;\n", tableName, columns.where(row)))
+	}
+	for _, row := range updated {
+		builder.WriteString(fmt.Sprintf("UPDATE %s SET %s WHERE %s;\n", tableName, columns.set(row), columns.where(row)))
+	}
+	for _, row := range inserted {
+		builder.WriteString(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);\n", tableName, columns.names(), columns.values(row)))
+	}
+	builder.WriteString("COMMIT;\n")
+
+	return builder.String()
+}
+
+// GenerateMigrationSql 生成增量升级的 SQL 文件
+func GenerateMigrationSql(oldTable, newTable *Table, sqlFilepath, tableName string) error {
+	filepath := sqlFilepath
+	file, writer := createFile(filepath)
+	if file == nil {
+		return fmt.Errorf("create file://%s error", filepath)
+	}
+	defer file.Close()
+
+	_, err := writer.WriteString(MigrationSql(oldTable, newTable, tableName))
+	if err != nil {
+		return fmt.Errorf("write file://%s error: %s", filepath, err.Error())
+	}
+	err = writer.Flush()
+	if err != nil {
+		return fmt.Errorf("flush file://%s error: %s", filepath, err.Error())
+	}
+
+	return nil
+}
+
+// migrationRows 取得表的所有行，行的值同 GenerateSql 生成的 INSERT 语句
+func migrationRows(table *Table, columns migrationColumns) []migrationRow {
+	rows := make([]migrationRow, 0)
+	for _, provinceDistrict := range table.Provinces {
+		// 省/自治区/直辖市
+		rows = append(rows, columns.row(DictDistrict{
+			ProvinceCode: provinceDistrict.Code,
+			Level:        provinceDistrict.Level,
+			ProvinceName: provinceDistrict.Name,
+		}, provinceDistrict.Pinyin, provinceDistrict.PinyinInitials, provinceDistrict.EnglishName,
+			provinceDistrict.AreaCode, provinceDistrict.PostalCode))
+
+		for _, cityDistrict := range provinceDistrict.Cities {
+			// 市/州/盟
+			row := DictDistrict{
+				ProvinceCode: provinceDistrict.Code,
+				CityCode:     cityDistrict.Code,
+				Level:        cityDistrict.Level,
+				ProvinceName: provinceDistrict.Name,
+				CityName:     cityDistrict.Name,
+			}
+			rows = append(rows, columns.row(row, cityDistrict.Pinyin, cityDistrict.PinyinInitials, cityDistrict.EnglishName,
+				cityDistrict.AreaCode, cityDistrict.PostalCode))
+			rows = append(rows, columns.townshipRows(row, cityDistrict.AreaCode, cityDistrict.PostalCode, cityDistrict.Townships)...)
+
+			for _, countyDistrict := range cityDistrict.Counties {
+				// 县/县级市/旗
+				row := DictDistrict{
+					ProvinceCode: provinceDistrict.Code,
+					CityCode:     cityDistrict.Code,
+					CountyCode:   countyDistrict.Code,
+					Level:        countyDistrict.Level,
+					ProvinceName: provinceDistrict.Name,
+					CityName:     cityDistrict.Name,
+					CountyName:   countyDistrict.Name,
+				}
+				countyRow := columns.row(row, countyDistrict.Pinyin, countyDistrict.PinyinInitials, countyDistrict.EnglishName,
+					countyDistrict.AreaCode, countyDistrict.PostalCode)
+				countyRow.Synthetic = countyDistrict.Synthetic
+				rows = append(rows, countyRow)
+				rows = append(rows, columns.townshipRows(row, countyDistrict.AreaCode, countyDistrict.PostalCode, countyDistrict.Townships)...)
+			}
+		}
+	}
+	return rows
+}
+
+// row 取得一行，未启用的列值为空
+func (c *migrationColumns) row(district DictDistrict, pinyin, pinyinInitials, englishName, areaCode, postalCode string) migrationRow {
+	row := migrationRow{DictDistrict: district}
+	if c.withPinyin {
+		row.Pinyin = pinyin
+		row.PinyinInitials = pinyinInitials
+		row.EnglishName = englishName
+	}
+	if c.withPostal {
+		row.AreaCode = areaCode
+		row.PostalCode = postalCode
+	}
+	return row
+}
+
+// townshipRows 取得乡级和村级的行，同 GenerateSql 一样拼音列为空，电话区号和邮政编码为所在县级的
+func (c *migrationColumns) townshipRows(parent DictDistrict, areaCode, postalCode string, townships []Township) []migrationRow {
+	rows := make([]migrationRow, 0)
+	for _, township := range townships {
+		district := parent
+		district.Level = township.Level
+		row := c.row(district, "", "", "", areaCode, postalCode)
+		row.TownshipCode = township.Code
+		row.TownshipName = township.Name
+		rows = append(rows, row)
+
+		if !c.withVillage {
+			continue
+		}
+		for _, village := range township.Villages {
+			row := row
+			row.Level = village.Level
+			row.VillageCode = village.Code
+			row.VillageName = village.Name
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// names 取得 INSERT 语句的列名
+func (c *migrationColumns) names() string {
+	names := []string{"f_province_code", "f_city_code", "f_county_code", "f_level", "f_province_name", "f_city_name", "f_county_name"}
+	if c.withPinyin {
+		names = append(names, "f_pinyin", "f_pinyin_initials", "f_english_name")
+	}
+	if c.withPostal {
+		names = append(names, "f_area_code", "f_postal_code")
+	}
+	if c.withTownship {
+		names = append(names, "f_township_code", "f_township_name")
+		if c.withVillage {
+			names = append(names, "f_village_code", "f_village_name")
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// values 取得 INSERT 语句的值，顺序同 names
+func (c *migrationColumns) values(row migrationRow) string {
+	values := fmt.Sprintf("%d,%d,%d,%d,'%s','%s','%s'",
+		row.ProvinceCode, row.CityCode, row.CountyCode, row.Level,
+		row.ProvinceName, row.CityName, row.CountyName)
+	values += sqlPinyinValues(c.withPinyin, row.Pinyin, row.PinyinInitials, row.EnglishName)
+	values += sqlPostalValues(c.withPostal, row.AreaCode, row.PostalCode)
+	if c.withTownship {
+		values += fmt.Sprintf(",%d,'%s'", row.TownshipCode, row.TownshipName)
+		if c.withVillage {
+			values += fmt.Sprintf(",%d,'%s'", row.VillageCode, row.VillageName)
+		}
+	}
+	return values
+}
+
+// set 取得 UPDATE 语句除主键外的所有列的赋值
+func (c *migrationColumns) set(row migrationRow) string {
+	set := fmt.Sprintf("f_level=%d,f_province_name='%s',f_city_name='%s',f_county_name='%s'",
+		row.Level, row.ProvinceName, row.CityName, row.CountyName)
+	if c.withPinyin {
+		set += fmt.Sprintf(",f_pinyin='%s',f_pinyin_initials='%s',f_english_name='%s'",
+			row.Pinyin, row.PinyinInitials, strings.ReplaceAll(row.EnglishName, "'", "''"))
+	}
+	if c.withPostal {
+		set += fmt.Sprintf(",f_area_code='%s',f_postal_code='%s'", row.AreaCode, row.PostalCode)
+	}
+	if c.withTownship {
+		set += fmt.Sprintf(",f_township_name='%s'", row.TownshipName)
+		if c.withVillage {
+			set += fmt.Sprintf(",f_village_name='%s'", row.VillageName)
+		}
+	}
+	return set
+}
+
+// where 取得按主键定位一行的条件
+func (c *migrationColumns) where(row migrationRow) string {
+	where := fmt.Sprintf("f_province_code=%d AND f_city_code=%d AND f_county_code=%d",
+		row.ProvinceCode, row.CityCode, row.CountyCode)
+	if c.withTownship {
+		where += fmt.Sprintf(" AND f_township_code=%d", row.TownshipCode)
+		if c.withVillage {
+			where += fmt.Sprintf(" AND f_village_code=%d", row.VillageCode)
+		}
+	}
+	return where
+}
+
+func indexRows(rows []migrationRow) map[rowKey]migrationRow {
+	table := make(map[rowKey]migrationRow, len(rows))
+	for _, row := range rows {
+		table[rowKey{row.ProvinceCode, row.CityCode, row.CountyCode, row.TownshipCode, row.VillageCode}] = row
+	}
+	return table
+}
+
+func sortRows(rows []migrationRow) {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].ProvinceCode != rows[j].ProvinceCode {
+			return rows[i].ProvinceCode < rows[j].ProvinceCode
+		}
+		if rows[i].CityCode != rows[j].CityCode {
+			return rows[i].CityCode < rows[j].CityCode
+		}
+		if rows[i].CountyCode != rows[j].CountyCode {
+			return rows[i].CountyCode < rows[j].CountyCode
+		}
+		if rows[i].TownshipCode != rows[j].TownshipCode {
+			return rows[i].TownshipCode < rows[j].TownshipCode
+		}
+		return rows[i].VillageCode < rows[j].VillageCode
+	})
+}
@@ -0,0 +1,187 @@
+// Package district
+package district
+
+import (
+	"strings"
+)
+
+// districtSuffixes 行政区名的后缀，长的在前
+var districtSuffixes = []string{
+	"特别行政区", "行政委员会", "省", "市", "地区", "盟", "新区", "林区", "区", "县", "旗", "州",
+}
+
+// autonomousSuffixes 民族自治地方的后缀
+var autonomousSuffixes = []string{"自治区", "自治州", "自治县", "自治旗"}
+
+// ethnicNames 少数民族名（不含“族”字），长的在前以免“土家”被当作“土”
+var ethnicNames = []string{
+	"乌孜别克", "柯尔克孜", "维吾尔", "哈萨克", "达斡尔", "鄂温克", "鄂伦春", "塔吉克", "塔塔尔", "俄罗斯",
+	"蒙古", "布依", "朝鲜", "土家", "哈尼", "傈僳", "高山", "拉祜", "东乡", "纳西", "景颇", "仫佬", "布朗",
+	"撒拉", "毛南", "仡佬", "锡伯", "阿昌", "普米", "德昂", "保安", "裕固", "独龙", "赫哲", "门巴", "珞巴",
+	"基诺", "回", "藏", "苗", "彝", "壮", "满", "侗", "瑶", "白", "傣", "黎", "佤", "畲", "水", "土", "羌",
+	"怒", "京", "各",
+}
+
+// Normalizer 行政区名规范化器，将简名或后缀不同的行政区名（如：广东/珠海/香洲）规范为标准的行政区名
+type Normalizer struct {
+	provinces []*addressNode
+}
+
+// NewNormalizer 从 t_dict_district 表的行（或 Table.Rows()）新建规范化器
+func NewNormalizer(rows []DictDistrict) *Normalizer {
+	n := &Normalizer{provinces: make([]*addressNode, 0)}
+	provinceTable := make(map[uint32]*addressNode)
+	cityTable := make(map[uint32]*addressNode)
+
+	for _, row := range rows {
+		if row.CityCode == 0 && row.CountyCode == 0 {
+			province := newAddressNode(row.ProvinceCode, row.ProvinceName, nil)
+			provinceTable[row.ProvinceCode] = province
+			n.provinces = append(n.provinces, province)
+		}
+	}
+	for _, row := range rows {
+		if province, ok := provinceTable[row.ProvinceCode]; ok && row.CityCode != 0 && row.CountyCode == 0 {
+			cityTable[row.CityCode] = newAddressNode(row.CityCode, row.CityName, province)
+		}
+	}
+	for _, row := range rows {
+		if city, ok := cityTable[row.CityCode]; ok && row.CountyCode != 0 {
+			newAddressNode(row.CountyCode, row.CountyName, city)
+		}
+	}
+
+	return n
+}
+
+// Normalize 规范化行政区名，可省略省/市/区/县/自治州等后缀，如：
+// 广东/珠海/香洲 => 广东省/珠海市/香洲区，湖北/恩施 => 湖北省/恩施土家族苗族自治州，北京/海淀 => 北京市/海淀区；
+// 找不到或有歧义时返回 nil
+func (n *Normalizer) Normalize(name *Name) *Name {
+	province := findNormalized(n.provinces, name.ProvinceName)
+	if province == nil {
+		return nil
+	}
+	result := &Name{ProvinceName: province.name}
+	cityName, countyName := name.CityName, name.CountyName
+
+	// 直辖市重复的市名，如：北京/北京/海淀
+	if IsMunicipalityCode(province.code) && findNormalized([]*addressNode{province}, cityName) != nil {
+		cityName, countyName = countyName, ""
+	}
+	if len(cityName) == 0 {
+		return result
+	}
+
+	city := findNormalized(province.children, cityName)
+	if city == nil {
+		return nil
+	}
+	result.CityName = city.name
+	if len(countyName) == 0 {
+		return result
+	}
+
+	county := findNormalized(city.children, countyName)
+	if county == nil {
+		return nil
+	}
+	result.CountyName = county.name
+	return result
+}
+
+// findNormalized 在 nodes 中找与 name 匹配的行政区，全名优先，其次简名，有歧义时返回 nil
+func findNormalized(nodes []*addressNode, name string) *addressNode {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return nil
+	}
+
+	for _, node := range nodes {
+		if node.name == name {
+			return node
+		}
+	}
+
+	var found *addressNode
+	shortName := shortDistrictName(name)
+	for _, node := range nodes {
+		for _, alias := range node.aliases {
+			if alias == name || alias == shortName {
+				if found != nil && found != node {
+					return nil
+				}
+				found = node
+			}
+		}
+	}
+	return found
+}
+
+// ParseName 解析斜杠分隔的行政区名，如：广东/珠海/香洲
+func ParseName(s string) *Name {
+	parts := strings.Split(s, "/")
+	name := &Name{ProvinceName: strings.TrimSpace(parts[0])}
+	if len(parts) > 1 {
+		name.CityName = strings.TrimSpace(parts[1])
+	}
+	if len(parts) > 2 {
+		name.CountyName = strings.TrimSpace(parts[2])
+	}
+	return name
+}
+
+// shortDistrictName 取得行政区的简名，即去掉省/市/区/县等后缀，民族自治地方再去掉民族名，
+// 去掉后不足两个字的保留全名，如：广西壮族自治区 => 广西，恩施土家族苗族自治州 => 恩施，珠海市 => 珠海，东区 => 东区
+func shortDistrictName(name string) string {
+	for _, suffix := range autonomousSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			shortName := trimEthnicNames(strings.TrimSuffix(name, suffix))
+			if len([]rune(shortName)) >= 2 {
+				return shortName
+			}
+			return name
+		}
+	}
+
+	for _, suffix := range districtSuffixes {
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		shortName := strings.TrimSuffix(name, suffix)
+		if len([]rune(shortName)) >= 2 {
+			return shortName
+		}
+		break
+	}
+	return name
+}
+
+// trimEthnicNames 去掉末尾的民族名，如：恩施土家族苗族 => 恩施，伊犁哈萨克 => 伊犁
+func trimEthnicNames(name string) string {
+	// 带“族”字的，可能有多个，如：积石山保安族东乡族撒拉族
+	original := name
+	for trimmed := true; trimmed; {
+		trimmed = false
+		for _, ethnicName := range ethnicNames {
+			shortName := strings.TrimSuffix(name, ethnicName+"族")
+			if shortName != name && len([]rune(shortName)) >= 2 {
+				name = shortName
+				trimmed = true
+				break
+			}
+		}
+	}
+	if name != original {
+		return name
+	}
+
+	// 不带“族”字的，只有一个，如：伊犁哈萨克
+	for _, ethnicName := range ethnicNames {
+		shortName := strings.TrimSuffix(name, ethnicName)
+		if shortName != name && len([]rune(shortName)) >= 2 {
+			return shortName
+		}
+	}
+	return name
+}
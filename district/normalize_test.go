@@ -0,0 +1,66 @@
+// Package district
+package district
+
+import (
+	"context"
+	"testing"
+)
+
+// go test -v -run="TestShortDistrictName$"
+func TestShortDistrictName(t *testing.T) {
+	cases := map[string]string{
+		"广东省":        "广东",
+		"广西壮族自治区":    "广西",
+		"内蒙古自治区":     "内蒙古",
+		"新疆维吾尔自治区":   "新疆",
+		"香港特别行政区":    "香港",
+		"珠海市":        "珠海",
+		"香洲区":        "香洲",
+		"浦东新区":       "浦东",
+		"恩施土家族苗族自治州": "恩施",
+		"伊犁哈萨克自治州":   "伊犁",
+		"积石山保安族东乡族撒拉族自治县": "积石山",
+		"隆林各族自治县":         "隆林",
+		"阿拉善盟":            "阿拉善",
+		"恩施州":             "恩施",
+		"广州":              "广州",
+		"东区":              "东区",
+		"矿区":              "矿区",
+	}
+	for name, expect := range cases {
+		if shortName := shortDistrictName(name); shortName != expect {
+			t.Errorf("shortDistrictName(%s): %s, expect %s\n", name, shortName, expect)
+		}
+	}
+}
+
+// go test -v -run="TestNormalize$"
+func TestNormalize(t *testing.T) {
+	ctx := context.Background()
+	table, err := LoadDistrict(ctx, "../district-2023.csv")
+	if err != nil {
+		t.Fatalf("LoadDistrict error: %s\n", err.Error())
+	}
+	query := NewMemoryQuery(table)
+
+	cases := map[string]Code{
+		"广东/珠海/香洲":      {440000, 440400, 440402},
+		"广东省/珠海/香洲区":    {440000, 440400, 440402},
+		"湖北/恩施":         {420000, 422800, 0},
+		"湖北/恩施州/恩施市":    {420000, 422800, 422801},
+		"北京/海淀":         {110000, 110108, 0},
+		"北京/北京/海淀":      {110000, 110108, 0},
+		"新疆/伊犁/察布查尔":    {650000, 654000, 654022},
+		"内蒙古/阿拉善/阿拉善左旗": {150000, 152900, 152921},
+	}
+	for s, expect := range cases {
+		code, err := query.GetDistrictCode(ctx, ParseName(s))
+		if err != nil || code == nil || *code != expect {
+			t.Errorf("GetDistrictCode(%s): %v %v\n", s, code, err)
+		}
+	}
+
+	if code, _ := query.GetDistrictCode(ctx, ParseName("广东/珠海/香洲X")); code != nil {
+		t.Errorf("GetDistrictCode(广东/珠海/香洲X): %v\n", *code)
+	}
+}
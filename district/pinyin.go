@@ -0,0 +1,108 @@
+// Package district
+package district
+
+import (
+	"github.com/mozillazg/go-pinyin"
+	"strings"
+)
+
+// pinyinPhrases 地名中的多音字词，优先于单字
+var pinyinPhrases = map[string][]string{
+	"六安": {"lu", "an"},
+	"东阿": {"dong", "e"},
+	"番禺": {"pan", "yu"},
+	"乐亭": {"lao", "ting"},
+	"乐清": {"yue", "qing"},
+	"单县": {"shan", "xian"},
+	"莘县": {"shen", "xian"},
+	"蔚县": {"yu", "xian"},
+	"洪洞": {"hong", "tong"},
+	"涡阳": {"guo", "yang"},
+	"牟平": {"mu", "ping"},
+	"覃塘": {"qin", "tang"},
+	"铅山": {"yan", "shan"},
+}
+
+// pinyinChars 地名中读音同 go-pinyin 默认读音不同的字
+var pinyinChars = map[rune]string{
+	'都': "du",    // 成都、都匀
+	'长': "chang", // 长沙、长春
+	'藏': "zang",  // 西藏
+	'厦': "xia",   // 厦门
+	'重': "chong", // 重庆
+	'什': "shi",   // 喀什
+	'浚': "xun",   // 浚县
+	'蚌': "beng",  // 蚌埠
+	'歙': "she",   // 歙县
+	'陂': "pi",    // 黄陂
+}
+
+var pinyinArgs = pinyin.NewArgs()
+
+// Pinyin 取得行政区名的拼音，每个字一个音节，如：珠海市 => [zhu hai shi]，非汉字原样保留
+func Pinyin(name string) []string {
+	syllables := make([]string, 0)
+	runes := []rune(name)
+
+	for i := 0; i < len(runes); {
+		if i+1 < len(runes) {
+			if phrase, ok := pinyinPhrases[string(runes[i:i+2])]; ok {
+				syllables = append(syllables, phrase...)
+				i += 2
+				continue
+			}
+		}
+
+		if syllable, ok := pinyinChars[runes[i]]; ok {
+			syllables = append(syllables, syllable)
+		} else if result := pinyin.LazyPinyin(string(runes[i]), pinyinArgs); len(result) > 0 {
+			syllables = append(syllables, result[0])
+		} else {
+			syllables = append(syllables, strings.ToLower(string(runes[i])))
+		}
+		i++
+	}
+	return syllables
+}
+
+// PinyinInitials 取得行政区名的拼音首字母，如：珠海市 => zhs
+func PinyinInitials(name string) string {
+	var builder strings.Builder
+	for _, syllable := range Pinyin(name) {
+		builder.WriteByte(syllable[0])
+	}
+	return builder.String()
+}
+
+// Romanize 取得行政区名的罗马字拼写，专名连写、通名分写，首字母大写，如：
+// 珠海市 => Zhuhai Shi，香洲区 => Xiangzhou Qu，恩施土家族苗族自治州 => Enshi Tujiazu Miaozu Zizhizhou
+func Romanize(name string) string {
+	words := make([]string, 0)
+	shortName := shortDistrictName(name)
+	rest := strings.TrimPrefix(name, shortName)
+	words = append(words, shortName)
+
+	// 民族名，如：土家族、苗族
+	for found := true; found && len(rest) > 0; {
+		found = false
+		for _, ethnicName := range ethnicNames {
+			if strings.HasPrefix(rest, ethnicName+"族") {
+				words = append(words, ethnicName+"族")
+				rest = strings.TrimPrefix(rest, ethnicName+"族")
+				found = true
+				break
+			}
+		}
+	}
+	if len(rest) > 0 {
+		words = append(words, rest)
+	}
+
+	for i, word := range words {
+		syllables := strings.Join(Pinyin(word), "")
+		if len(syllables) > 0 {
+			words[i] = strings.ToUpper(syllables[:1]) + syllables[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,182 @@
+// Package district
+package district
+
+import (
+	"sort"
+	"strings"
+)
+
+// provinceAbbreviations 省级行政区的简称，同时也是机动车号牌的省份汉字
+var provinceAbbreviations = map[uint32]string{
+	110000: "京", 120000: "津", 130000: "冀", 140000: "晋", 150000: "蒙",
+	210000: "辽", 220000: "吉", 230000: "黑",
+	310000: "沪", 320000: "苏", 330000: "浙", 340000: "皖", 350000: "闽", 360000: "赣", 370000: "鲁",
+	410000: "豫", 420000: "鄂", 430000: "湘", 440000: "粤", 450000: "桂", 460000: "琼",
+	500000: "渝", 510000: "川", 520000: "贵", 530000: "云", 540000: "藏",
+	610000: "陕", 620000: "甘", 630000: "青", 640000: "宁", 650000: "新",
+	710000: "台", 810000: "港", 820000: "澳",
+}
+
+// plateLetters 机动车号牌的发牌机关代号，键为市级行政区代码（直辖市为省级行政区代码），
+// 值为所有的代号字母，如：440600 => EXY ，表示佛山的号牌前缀有粤E、粤X和粤Y
+var plateLetters = map[uint32]string{
+	// 直辖市
+	110000: "ABCEFGHJKLMNPQY", 120000: "ABCDEFGHJKLMNQR", 310000: "ABCDEFGHJKLMNR", 500000: "ABCDFGH",
+	// 河北
+	130100: "A", 130200: "B", 130300: "C", 130400: "D", 130500: "E", 130600: "F", 130700: "G", 130800: "H",
+	130900: "J", 131000: "R", 131100: "T",
+	// 山西
+	140100: "A", 140200: "B", 140300: "C", 140400: "D", 140500: "E", 140600: "F", 140700: "K", 140800: "M",
+	140900: "H", 141000: "L", 141100: "J",
+	// 内蒙古
+	150100: "A", 150200: "B", 150300: "C", 150400: "D", 150500: "G", 150600: "K", 150700: "E", 150800: "L",
+	150900: "J", 152200: "F", 152500: "H", 152900: "M",
+	// 辽宁
+	210100: "A", 210200: "B", 210300: "C", 210400: "D", 210500: "E", 210600: "F", 210700: "G", 210800: "H",
+	210900: "J", 211000: "K", 211100: "L", 211200: "M", 211300: "N", 211400: "P",
+	// 吉林
+	220100: "A", 220200: "B", 220300: "C", 220400: "D", 220500: "E", 220600: "F", 220700: "J", 220800: "G",
+	222400: "H",
+	// 黑龙江
+	230100: "A", 230200: "B", 230300: "G", 230400: "H", 230500: "J", 230600: "E", 230700: "F", 230800: "D",
+	230900: "K", 231000: "C", 231100: "N", 231200: "M", 232700: "P",
+	// 江苏
+	320100: "A", 320200: "B", 320300: "C", 320400: "D", 320500: "EU", 320600: "F", 320700: "G", 320800: "H",
+	320900: "J", 321000: "K", 321100: "L", 321200: "M", 321300: "N",
+	// 浙江
+	330100: "A", 330200: "B", 330300: "C", 330400: "F", 330500: "E", 330600: "D", 330700: "G", 330800: "H",
+	330900: "L", 331000: "J", 331100: "K",
+	// 安徽
+	340100: "A", 340200: "B", 340300: "C", 340400: "D", 340500: "E", 340600: "F", 340700: "G", 340800: "H",
+	341000: "J", 341100: "M", 341200: "K", 341300: "L", 341500: "N", 341600: "S", 341700: "R", 341800: "P",
+	// 福建
+	350100: "A", 350200: "D", 350300: "B", 350400: "G", 350500: "C", 350600: "E", 350700: "H", 350800: "F",
+	350900: "J",
+	// 江西
+	360100: "A", 360200: "H", 360300: "J", 360400: "G", 360500: "K", 360600: "L", 360700: "B", 360800: "D",
+	360900: "C", 361000: "F", 361100: "E",
+	// 山东
+	370100: "AS", 370200: "BU", 370300: "C", 370400: "D", 370500: "E", 370600: "FY", 370700: "GV", 370800: "H",
+	370900: "J", 371000: "K", 371100: "L", 371300: "Q", 371400: "N", 371500: "P", 371600: "M", 371700: "R",
+	// 河南
+	410100: "A", 410200: "B", 410300: "C", 410400: "D", 410500: "E", 410600: "F", 410700: "G", 410800: "H",
+	410900: "J", 411000: "K", 411100: "L", 411200: "M", 411300: "R", 411400: "N", 411500: "S", 411600: "P",
+	411700: "Q", 419001: "U",
+	// 湖北
+	420100: "A", 420200: "B", 420300: "C", 420500: "E", 420600: "F", 420700: "G", 420800: "H", 420900: "K",
+	421000: "D", 421100: "J", 421200: "L", 421300: "S", 422800: "Q", 429004: "M", 429005: "N", 429006: "R",
+	429021: "P",
+	// 湖南
+	430100: "A", 430200: "B", 430300: "C", 430400: "D", 430500: "E", 430600: "F", 430700: "J", 430800: "G",
+	430900: "H", 431000: "L", 431100: "M", 431200: "N", 431300: "K", 433100: "U",
+	// 广东
+	440100: "A", 440200: "F", 440300: "B", 440400: "C", 440500: "D", 440600: "EXY", 440700: "J", 440800: "G",
+	440900: "K", 441200: "H", 441300: "L", 441400: "M", 441500: "N", 441600: "P", 441700: "Q", 441800: "R",
+	441900: "S", 442000: "T", 445100: "U", 445200: "V", 445300: "W",
+	// 广西
+	450100: "A", 450200: "B", 450300: "CH", 450400: "D", 450500: "E", 450600: "P", 450700: "N", 450800: "R",
+	450900: "K", 451000: "L", 451100: "J", 451200: "M", 451300: "G", 451400: "F",
+	// 海南
+	460100: "A", 460200: "B", 460400: "F",
+	// 四川
+	510100: "AG", 510300: "C", 510400: "D", 510500: "E", 510600: "F", 510700: "B", 510800: "H", 510900: "J",
+	511000: "K", 511100: "L", 511300: "R", 511400: "Z", 511500: "Q", 511600: "X", 511700: "S", 511800: "T",
+	511900: "Y", 512000: "M", 513200: "U", 513300: "V", 513400: "W",
+	// 贵州
+	520100: "A", 520200: "B", 520300: "C", 520400: "G", 520500: "F", 520600: "D", 522300: "E", 522600: "H",
+	522700: "J",
+	// 云南
+	530100: "A", 530300: "D", 530400: "F", 530500: "M", 530600: "C", 530700: "P", 530800: "J", 530900: "S",
+	532300: "E", 532500: "G", 532600: "H", 532800: "K", 532900: "L", 533100: "N", 533300: "Q", 533400: "R",
+	// 西藏
+	540100: "A", 540200: "D", 540300: "B", 540400: "G", 540500: "C", 540600: "E", 542500: "F",
+	// 陕西
+	610100: "A", 610200: "B", 610300: "C", 610400: "D", 610500: "E", 610600: "J", 610700: "F", 610800: "K",
+	610900: "G", 611000: "H",
+	// 甘肃
+	620100: "A", 620200: "B", 620300: "C", 620400: "D", 620500: "E", 620600: "H", 620700: "G", 620800: "L",
+	620900: "F", 621000: "M", 621100: "J", 621200: "K", 622900: "N", 623000: "P",
+	// 青海
+	630100: "A", 630200: "B", 632200: "C", 632300: "D", 632500: "E", 632600: "F", 632700: "G", 632800: "H",
+	// 宁夏
+	640100: "A", 640200: "B", 640300: "C", 640400: "D", 640500: "E",
+	// 新疆
+	650100: "A", 650200: "J", 650400: "K", 650500: "L", 652300: "B", 652700: "E", 652800: "M", 652900: "N",
+	653000: "P", 653100: "Q", 653200: "R", 654000: "F", 654200: "G", 654300: "H",
+}
+
+// ProvinceAbbreviation 取得省级行政区的简称，如：440000 => 粤，code 可为任一级行政区代码，未知的返回空字符串
+func ProvinceAbbreviation(code uint32) string {
+	return provinceAbbreviations[getProvinceDistrictCode(code)]
+}
+
+// DecodePlate 解析机动车号牌（或号牌前缀）所属的行政区，如：粤C12345 => 广东省/珠海市，
+// 只有省份汉字的（如：粤）返回省级行政区，直辖市的号牌返回直辖市；不能识别或不在 Table 中时都返回 nil
+func (t *Table) DecodePlate(plate string) (*Code, *Name) {
+	runes := []rune(strings.TrimSpace(plate))
+	if len(runes) == 0 {
+		return nil, nil
+	}
+
+	var provinceCode uint32
+	for code, abbreviation := range provinceAbbreviations {
+		if abbreviation == string(runes[0]) {
+			provinceCode = code
+			break
+		}
+	}
+	if provinceCode == 0 {
+		return nil, nil
+	}
+	if len(runes) == 1 {
+		return t.FindCode(provinceCode)
+	}
+
+	letter := strings.ToUpper(string(runes[1]))
+	if IsMunicipalityCode(provinceCode) {
+		if !strings.Contains(plateLetters[provinceCode], letter) {
+			return nil, nil
+		}
+		return t.FindCode(provinceCode)
+	}
+	for code, letters := range plateLetters {
+		if getProvinceDistrictCode(code) == provinceCode && strings.Contains(letters, letter) {
+			return t.FindCode(code)
+		}
+	}
+	return nil, nil
+}
+
+// PlatePrefixes 取得行政区的机动车号牌前缀，如：珠海市或香洲区 => [粤C]，
+// 省级行政区返回省内所有的号牌前缀，没有或不在 Table 中的返回空数组
+func (t *Table) PlatePrefixes(code *Code) []string {
+	prefixes := make([]string, 0)
+	abbreviation := ProvinceAbbreviation(code.ProvinceCode)
+	if len(abbreviation) == 0 {
+		return prefixes
+	}
+	if c, _ := t.FindCode(code.Lowest()); c == nil {
+		return prefixes // 不在 Table 中
+	}
+
+	var codes []uint32
+	if IsMunicipalityCode(code.ProvinceCode) {
+		codes = []uint32{code.ProvinceCode}
+	} else if code.CityCode != 0 {
+		codes = []uint32{code.CityCode}
+	} else {
+		for cityCode := range plateLetters {
+			if getProvinceDistrictCode(cityCode) == code.ProvinceCode {
+				codes = append(codes, cityCode)
+			}
+		}
+	}
+
+	for _, c := range codes {
+		for _, letter := range plateLetters[c] {
+			prefixes = append(prefixes, abbreviation+string(letter))
+		}
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
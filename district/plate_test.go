@@ -0,0 +1,60 @@
+// Package district
+package district
+
+import (
+	"context"
+	"testing"
+)
+
+// go test -v -run="TestPlate$"
+func TestPlate(t *testing.T) {
+	table, err := LoadDistrict(context.Background(), "../district-2023.csv")
+	if err != nil {
+		t.Fatalf("LoadDistrict error: %s\n", err.Error())
+	}
+
+	// 所有发牌机关代号对应的行政区都应存在
+	for code := range plateLetters {
+		if c, _ := table.FindCode(code); c == nil {
+			t.Errorf("plateLetters: %d not found\n", code)
+		}
+	}
+	if abbreviation := table.ProvinceDistrictTable[150000].Abbreviation; abbreviation != "蒙" {
+		t.Errorf("Abbreviation(150000): %s\n", abbreviation)
+	}
+
+	cases := map[string]string{
+		"粤C12345": "广东省/珠海市/",
+		"粤c":      "广东省/珠海市/",
+		"粤":       "广东省//",
+		"京N":      "北京市//",
+		"豫U":      "河南省/济源市/",
+	}
+	for plate, expect := range cases {
+		_, name := table.DecodePlate(plate)
+		if name == nil {
+			t.Errorf("DecodePlate(%s): nil\n", plate)
+		} else if s := name.ProvinceName + "/" + name.CityName + "/" + name.CountyName; s != expect {
+			t.Errorf("DecodePlate(%s): %s, expect %s\n", plate, s, expect)
+		}
+	}
+	for _, plate := range []string{"", "A12345", "粤I", "京Z"} {
+		if code, _ := table.DecodePlate(plate); code != nil {
+			t.Errorf("DecodePlate(%s): %v\n", plate, *code)
+		}
+	}
+
+	prefixes := table.PlatePrefixes(&Code{ProvinceCode: 440000, CityCode: 440400, CountyCode: 440402})
+	if len(prefixes) != 1 || prefixes[0] != "粤C" {
+		t.Errorf("PlatePrefixes(440402): %v\n", prefixes)
+	}
+	if prefixes = table.PlatePrefixes(&Code{ProvinceCode: 440000, CityCode: 440600}); len(prefixes) != 3 {
+		t.Errorf("PlatePrefixes(440600): %v\n", prefixes)
+	}
+	if prefixes = table.PlatePrefixes(&Code{ProvinceCode: 440000}); len(prefixes) != 23 {
+		t.Errorf("PlatePrefixes(440000): %v\n", prefixes)
+	}
+	if prefixes = table.PlatePrefixes(&Code{ProvinceCode: 990000}); len(prefixes) != 0 {
+		t.Errorf("PlatePrefixes(990000): %v\n", prefixes)
+	}
+}
@@ -0,0 +1,189 @@
+// Package district
+package district
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// PostalInfo 行政区的电话区号和邮政编码
+type PostalInfo struct {
+	AreaCode   string `json:"area_code,omitempty"`   // 电话区号，如：0756
+	PostalCode string `json:"postal_code,omitempty"` // 邮政编码，如：519000
+}
+
+// LoadPostalInfo 从辅助文件加载行政区的电话区号和邮政编码，每行格式为：行政区代码,电话区号,邮政编码，
+// 电话区号和邮政编码都可为空，首个非注释行为标题时跳过，以 # 开头的行为注释
+func LoadPostalInfo(ctx context.Context, filepath string) (map[uint32]PostalInfo, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return LoadPostalInfoFromReader(ctx, file)
+}
+
+// LoadPostalInfoFromReader 从 io.Reader 加载行政区的电话区号和邮政编码，格式同 LoadPostalInfo
+func LoadPostalInfoFromReader(ctx context.Context, r io.Reader) (map[uint32]PostalInfo, error) {
+	postalInfos := make(map[uint32]PostalInfo)
+	reader, err := newBufferedReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	lineNo := 0
+	titled := false // 已过首个非注释行
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		lineNo = lineNo + 1
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			if len(line) == 0 {
+				break
+			}
+		}
+
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.Split(line, ",")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid row format: (%d) %s, expected format: DistrictCode,AreaCode,PostalCode", lineNo, line)
+		}
+		code, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 32)
+		if err != nil {
+			if !titled {
+				titled = true
+				continue // 标题行
+			}
+			return nil, fmt.Errorf("invalid district code: (%d) %s", lineNo, line)
+		}
+		titled = true
+
+		postalInfo := PostalInfo{
+			AreaCode:   strings.TrimSpace(parts[1]),
+			PostalCode: strings.TrimSpace(parts[2]),
+		}
+		if !isDigits(postalInfo.AreaCode) || !isDigits(postalInfo.PostalCode) {
+			return nil, fmt.Errorf("invalid area code or postal code: (%d) %s", lineNo, line)
+		}
+		postalInfos[uint32(code)] = postalInfo
+	}
+
+	return postalInfos, nil
+}
+
+// SetPostalInfo 设置行政区的电话区号和邮政编码，postalInfos 的键为 6 位行政区代码，
+// 不在 postalInfos 中的或值为空的，继承上一级行政区的（如：区县通常同所在的市共用电话区号）
+func (t *Table) SetPostalInfo(postalInfos map[uint32]PostalInfo) {
+	for i := range t.Provinces {
+		provinceDistrict := &t.Provinces[i]
+		provincePostalInfo := mergePostalInfo(postalInfos[provinceDistrict.Code], PostalInfo{})
+		provinceDistrict.AreaCode, provinceDistrict.PostalCode = provincePostalInfo.AreaCode, provincePostalInfo.PostalCode
+		p := t.ProvinceDistrictTable[provinceDistrict.Code]
+		p.AreaCode, p.PostalCode = provincePostalInfo.AreaCode, provincePostalInfo.PostalCode
+		t.ProvinceDistrictTable[provinceDistrict.Code] = p
+
+		for j := range provinceDistrict.Cities {
+			cityDistrict := &provinceDistrict.Cities[j]
+			cityPostalInfo := mergePostalInfo(postalInfos[cityDistrict.Code], provincePostalInfo)
+			cityDistrict.AreaCode, cityDistrict.PostalCode = cityPostalInfo.AreaCode, cityPostalInfo.PostalCode
+			c := provinceDistrict.CityDistrictTable[cityDistrict.Code]
+			c.AreaCode, c.PostalCode = cityPostalInfo.AreaCode, cityPostalInfo.PostalCode
+			provinceDistrict.CityDistrictTable[cityDistrict.Code] = c
+
+			for k := range cityDistrict.Counties {
+				countyDistrict := &cityDistrict.Counties[k]
+				countyPostalInfo := mergePostalInfo(postalInfos[countyDistrict.Code], cityPostalInfo)
+				countyDistrict.AreaCode, countyDistrict.PostalCode = countyPostalInfo.AreaCode, countyPostalInfo.PostalCode
+				cityDistrict.CountyDistrictTable[countyDistrict.Code] = *countyDistrict
+			}
+		}
+	}
+}
+
+// GetPostalInfo 取得行政区的电话区号和邮政编码，不在 Table 中的返回 nil
+func (t *Table) GetPostalInfo(code *Code) *PostalInfo {
+	provinceDistrict, ok := t.ProvinceDistrictTable[code.ProvinceCode]
+	if !ok {
+		return nil
+	}
+	if code.CityCode == 0 {
+		return &PostalInfo{AreaCode: provinceDistrict.AreaCode, PostalCode: provinceDistrict.PostalCode}
+	}
+
+	cityDistrict, ok := provinceDistrict.CityDistrictTable[code.CityCode]
+	if !ok {
+		return nil
+	}
+	if code.CountyCode == 0 {
+		return &PostalInfo{AreaCode: cityDistrict.AreaCode, PostalCode: cityDistrict.PostalCode}
+	}
+
+	countyDistrict, ok := cityDistrict.CountyDistrictTable[code.CountyCode]
+	if !ok {
+		return nil
+	}
+	return &PostalInfo{AreaCode: countyDistrict.AreaCode, PostalCode: countyDistrict.PostalCode}
+}
+
+// FindByAreaCode 通过电话区号取得行政区代码，继承自上一级的不重复返回，
+// 如：0756 => [珠海市]，而不是珠海市及其所有的区；不存在时返回空数组
+func (t *Table) FindByAreaCode(areaCode string) []Code {
+	codes := make([]Code, 0)
+	areaCode = strings.TrimSpace(areaCode)
+	if len(areaCode) == 0 {
+		return codes
+	}
+
+	for _, provinceDistrict := range t.Provinces {
+		if provinceDistrict.AreaCode == areaCode {
+			codes = append(codes, Code{ProvinceCode: provinceDistrict.Code})
+		}
+		for _, cityDistrict := range provinceDistrict.Cities {
+			if cityDistrict.AreaCode == areaCode && provinceDistrict.AreaCode != areaCode {
+				codes = append(codes, Code{ProvinceCode: provinceDistrict.Code, CityCode: cityDistrict.Code})
+			}
+			for _, countyDistrict := range cityDistrict.Counties {
+				if countyDistrict.AreaCode == areaCode && cityDistrict.AreaCode != areaCode {
+					codes = append(codes, Code{ProvinceCode: provinceDistrict.Code, CityCode: cityDistrict.Code, CountyCode: countyDistrict.Code})
+				}
+			}
+		}
+	}
+	return codes
+}
+
+// mergePostalInfo 为空的取上一级行政区的
+func mergePostalInfo(postalInfo, parent PostalInfo) PostalInfo {
+	if len(postalInfo.AreaCode) == 0 {
+		postalInfo.AreaCode = parent.AreaCode
+	}
+	if len(postalInfo.PostalCode) == 0 {
+		postalInfo.PostalCode = parent.PostalCode
+	}
+	return postalInfo
+}
+
+// isDigits 是否为空或全为数字
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
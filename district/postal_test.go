@@ -0,0 +1,62 @@
+// Package district
+package district
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// go test -v -run="TestPostalInfo$"
+func TestPostalInfo(t *testing.T) {
+	ctx := context.Background()
+	table, err := LoadDistrictFromReader(ctx, strings.NewReader(testDistrictData))
+	if err != nil {
+		t.Fatalf("LoadDistrictFromReader error: %s\n", err.Error())
+	}
+
+	data := "code,area_code,postal_code\n440400,0756,519000\n440403,,519100\n441900,0769,523000\n110000,010,100000"
+	postalInfos, err := LoadPostalInfoFromReader(ctx, strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadPostalInfoFromReader error: %s\n", err.Error())
+	}
+	table.SetPostalInfo(postalInfos)
+
+	cases := map[Code]PostalInfo{
+		{ProvinceCode: 440000}:                                       {},
+		{ProvinceCode: 440000, CityCode: 440400}:                     {AreaCode: "0756", PostalCode: "519000"},
+		{ProvinceCode: 440000, CityCode: 440400, CountyCode: 440402}: {AreaCode: "0756", PostalCode: "519000"},
+		{ProvinceCode: 440000, CityCode: 440400, CountyCode: 440403}: {AreaCode: "0756", PostalCode: "519100"},
+		{ProvinceCode: 110000, CityCode: 110108}:                     {AreaCode: "010", PostalCode: "100000"},
+	}
+	for code, expect := range cases {
+		postalInfo := table.GetPostalInfo(&code)
+		if postalInfo == nil || *postalInfo != expect {
+			t.Errorf("GetPostalInfo(%v): %v, expect %v\n", code, postalInfo, expect)
+		}
+	}
+	if postalInfo := table.GetPostalInfo(&Code{ProvinceCode: 440000, CityCode: 440500}); postalInfo != nil {
+		t.Errorf("GetPostalInfo(440500): %v\n", *postalInfo)
+	}
+	if areaCode := table.Provinces[1].Cities[0].Counties[1].AreaCode; areaCode != "0756" {
+		t.Errorf("Counties[1]: %s\n", areaCode)
+	}
+
+	codes := table.FindByAreaCode("0756")
+	if len(codes) != 1 || codes[0].CityCode != 440400 || codes[0].CountyCode != 0 {
+		t.Errorf("FindByAreaCode(0756): %v\n", codes)
+	}
+	if codes = table.FindByAreaCode("010"); len(codes) != 1 || codes[0].ProvinceCode != 110000 || codes[0].CityCode != 0 {
+		t.Errorf("FindByAreaCode(010): %v\n", codes)
+	}
+	if codes = table.FindByAreaCode("0000"); len(codes) != 0 {
+		t.Errorf("FindByAreaCode(0000): %v\n", codes)
+	}
+
+	if _, err := LoadPostalInfoFromReader(ctx, strings.NewReader("440400,0756\n")); err == nil {
+		t.Errorf("LoadPostalInfoFromReader: expect error\n")
+	}
+	if _, err := LoadPostalInfoFromReader(ctx, strings.NewReader("440400,0756,5190AB\n")); err == nil {
+		t.Errorf("LoadPostalInfoFromReader: expect error\n")
+	}
+}
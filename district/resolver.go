@@ -0,0 +1,30 @@
+// Package district
+package district
+
+import (
+	"context"
+)
+
+// Resolver 行政区解析器，屏蔽数据来源（数据库、内存、文件快照或远程服务等）
+type Resolver interface {
+	// GetDistrictCode 通过行政区名取得行政区代码，不存在时返回 nil 的 Code 和 nil 的 error
+	GetDistrictCode(ctx context.Context, name *Name) (*Code, error)
+
+	// GetDistrictName 通过行政区代码取得行政区名，不存在时返回 nil 的 Name 和 nil 的 error
+	GetDistrictName(ctx context.Context, code *Code) (*Name, error)
+
+	// GetCountyCount 取得县/县级市/旗数（计数包含市级行政区自身）
+	GetCountyCount(ctx context.Context, provinceName, cityName string) (int, error)
+
+	// GetChildren 取得下一级行政区列表：
+	// 1）code 全为 0 时返回所有省级行政区；
+	// 2）只有 ProvinceCode 时返回该省的市级行政区；
+	// 3）有 ProvinceCode 和 CityCode 时返回该市的县级行政区；
+	// 4）县级行政区没有下一级，返回空列表。
+	GetChildren(ctx context.Context, code *Code) ([]DictDistrict, error)
+}
+
+var (
+	_ Resolver = (*Query)(nil)
+	_ Resolver = (*MemoryQuery)(nil)
+)
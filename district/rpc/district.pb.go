@@ -0,0 +1,863 @@
+// 行政区查询服务的接口定义，Go 的代码由 protoc-gen-go 和 protoc-gen-go-grpc 生成：
+// protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative district.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: district.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Province 省/自治区/直辖市
+type Province struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          uint32                 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"` // 行政区代码，如：440000
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`  // 行政区名称，如：广东省
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Province) Reset() {
+	*x = Province{}
+	mi := &file_district_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Province) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Province) ProtoMessage() {}
+
+func (x *Province) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Province.ProtoReflect.Descriptor instead.
+func (*Province) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Province) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Province) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// City 市/州/盟，以及直辖市的区县和省直辖县级市（如：济源市）
+type City struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          uint32                 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"` // 行政区代码，如：440400
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`  // 行政区名称，如：珠海市
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *City) Reset() {
+	*x = City{}
+	mi := &file_district_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *City) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*City) ProtoMessage() {}
+
+func (x *City) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use City.ProtoReflect.Descriptor instead.
+func (*City) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *City) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *City) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// County 县/县级市/旗
+type County struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          uint32                 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"` // 行政区代码，如：440402
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`  // 行政区名称，如：香洲区
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *County) Reset() {
+	*x = County{}
+	mi := &file_district_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *County) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*County) ProtoMessage() {}
+
+func (x *County) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use County.ProtoReflect.Descriptor instead.
+func (*County) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *County) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *County) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// District 一个行政区及其上级行政区
+type District struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Province      *Province              `protobuf:"bytes,1,opt,name=province,proto3" json:"province,omitempty"`
+	City          *City                  `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`     // 省级行政区时为空
+	County        *County                `protobuf:"bytes,3,opt,name=county,proto3" json:"county,omitempty"` // 省级和市级行政区时为空
+	Level         uint32                 `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`  // 行政区级别（1 省/自治区/直辖市，2 市/州/盟，3 县/县级市/旗），直辖市的区县为 2
+	Path          string                 `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`     // 完整路径，如：广东省/珠海市/香洲区
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *District) Reset() {
+	*x = District{}
+	mi := &file_district_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *District) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*District) ProtoMessage() {}
+
+func (x *District) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use District.ProtoReflect.Descriptor instead.
+func (*District) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *District) GetProvince() *Province {
+	if x != nil {
+		return x.Province
+	}
+	return nil
+}
+
+func (x *District) GetCity() *City {
+	if x != nil {
+		return x.City
+	}
+	return nil
+}
+
+func (x *District) GetCounty() *County {
+	if x != nil {
+		return x.County
+	}
+	return nil
+}
+
+func (x *District) GetLevel() uint32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *District) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type GetByCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          uint32                 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"` // 6 位行政区代码，如：440402
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByCodeRequest) Reset() {
+	*x = GetByCodeRequest{}
+	mi := &file_district_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByCodeRequest) ProtoMessage() {}
+
+func (x *GetByCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByCodeRequest.ProtoReflect.Descriptor instead.
+func (*GetByCodeRequest) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetByCodeRequest) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+type GetByNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // 斜杠分隔的行政区名，可为简名，如：广东/珠海/香洲
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByNameRequest) Reset() {
+	*x = GetByNameRequest{}
+	mi := &file_district_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByNameRequest) ProtoMessage() {}
+
+func (x *GetByNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByNameRequest.ProtoReflect.Descriptor instead.
+func (*GetByNameRequest) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetByNameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListChildrenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          uint32                 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"` // 6 位行政区代码，为 0 时列出所有省级行政区
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChildrenRequest) Reset() {
+	*x = ListChildrenRequest{}
+	mi := &file_district_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChildrenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChildrenRequest) ProtoMessage() {}
+
+func (x *ListChildrenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChildrenRequest.ProtoReflect.Descriptor instead.
+func (*ListChildrenRequest) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListChildrenRequest) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+type ListChildrenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Districts     []*District            `protobuf:"bytes,1,rep,name=districts,proto3" json:"districts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChildrenResponse) Reset() {
+	*x = ListChildrenResponse{}
+	mi := &file_district_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChildrenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChildrenResponse) ProtoMessage() {}
+
+func (x *ListChildrenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChildrenResponse.ProtoReflect.Descriptor instead.
+func (*ListChildrenResponse) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListChildrenResponse) GetDistricts() []*District {
+	if x != nil {
+		return x.Districts
+	}
+	return nil
+}
+
+type BatchResolveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inputs        []string               `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"` // 6 位行政区代码或斜杠分隔的行政区名
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchResolveRequest) Reset() {
+	*x = BatchResolveRequest{}
+	mi := &file_district_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResolveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResolveRequest) ProtoMessage() {}
+
+func (x *BatchResolveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResolveRequest.ProtoReflect.Descriptor instead.
+func (*BatchResolveRequest) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchResolveRequest) GetInputs() []string {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+type ResolveResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Input         string                 `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	Found         bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	District      *District              `protobuf:"bytes,3,opt,name=district,proto3" json:"district,omitempty"` // found 为 false 时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveResult) Reset() {
+	*x = ResolveResult{}
+	mi := &file_district_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveResult) ProtoMessage() {}
+
+func (x *ResolveResult) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveResult.ProtoReflect.Descriptor instead.
+func (*ResolveResult) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ResolveResult) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *ResolveResult) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *ResolveResult) GetDistrict() *District {
+	if x != nil {
+		return x.District
+	}
+	return nil
+}
+
+type BatchResolveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ResolveResult       `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // 同 inputs 一一对应
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchResolveResponse) Reset() {
+	*x = BatchResolveResponse{}
+	mi := &file_district_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResolveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResolveResponse) ProtoMessage() {}
+
+func (x *BatchResolveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResolveResponse.ProtoReflect.Descriptor instead.
+func (*BatchResolveResponse) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchResolveResponse) GetResults() []*ResolveResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keyword       string                 `protobuf:"bytes,1,opt,name=keyword,proto3" json:"keyword,omitempty"` // 中文、全拼或拼音首字母，如：珠海、zhuhai、zh
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`    // 最多返回的结果数，小于等于 0 时不限制
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_district_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchRequest) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	District      *District              `protobuf:"bytes,1,opt,name=district,proto3" json:"district,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"` // 匹配得分，取值 0 到 1 ，越大越匹配
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_district_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchResult) GetDistrict() *District {
+	if x != nil {
+		return x.District
+	}
+	return nil
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // 按得分从高到低排序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_district_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_district_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_district_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SearchResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_district_proto protoreflect.FileDescriptor
+
+const file_district_proto_rawDesc = "" +
+	"\n" +
+	"\x0edistrict.proto\x12\x0emooon.district\"2\n" +
+	"\bProvince\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\rR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\".\n" +
+	"\x04City\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\rR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"0\n" +
+	"\x06County\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\rR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xc4\x01\n" +
+	"\bDistrict\x124\n" +
+	"\bprovince\x18\x01 \x01(\v2\x18.mooon.district.ProvinceR\bprovince\x12(\n" +
+	"\x04city\x18\x02 \x01(\v2\x14.mooon.district.CityR\x04city\x12.\n" +
+	"\x06county\x18\x03 \x01(\v2\x16.mooon.district.CountyR\x06county\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\rR\x05level\x12\x12\n" +
+	"\x04path\x18\x05 \x01(\tR\x04path\"&\n" +
+	"\x10GetByCodeRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\rR\x04code\"&\n" +
+	"\x10GetByNameRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\")\n" +
+	"\x13ListChildrenRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\rR\x04code\"N\n" +
+	"\x14ListChildrenResponse\x126\n" +
+	"\tdistricts\x18\x01 \x03(\v2\x18.mooon.district.DistrictR\tdistricts\"-\n" +
+	"\x13BatchResolveRequest\x12\x16\n" +
+	"\x06inputs\x18\x01 \x03(\tR\x06inputs\"q\n" +
+	"\rResolveResult\x12\x14\n" +
+	"\x05input\x18\x01 \x01(\tR\x05input\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\x124\n" +
+	"\bdistrict\x18\x03 \x01(\v2\x18.mooon.district.DistrictR\bdistrict\"O\n" +
+	"\x14BatchResolveResponse\x127\n" +
+	"\aresults\x18\x01 \x03(\v2\x1d.mooon.district.ResolveResultR\aresults\"?\n" +
+	"\rSearchRequest\x12\x18\n" +
+	"\akeyword\x18\x01 \x01(\tR\akeyword\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"Z\n" +
+	"\fSearchResult\x124\n" +
+	"\bdistrict\x18\x01 \x01(\v2\x18.mooon.district.DistrictR\bdistrict\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"H\n" +
+	"\x0eSearchResponse\x126\n" +
+	"\aresults\x18\x01 \x03(\v2\x1c.mooon.district.SearchResultR\aresults2\xa2\x03\n" +
+	"\x0fDistrictService\x12G\n" +
+	"\tGetByCode\x12 .mooon.district.GetByCodeRequest\x1a\x18.mooon.district.District\x12G\n" +
+	"\tGetByName\x12 .mooon.district.GetByNameRequest\x1a\x18.mooon.district.District\x12Y\n" +
+	"\fListChildren\x12#.mooon.district.ListChildrenRequest\x1a$.mooon.district.ListChildrenResponse\x12Y\n" +
+	"\fBatchResolve\x12#.mooon.district.BatchResolveRequest\x1a$.mooon.district.BatchResolveResponse\x12G\n" +
+	"\x06Search\x12\x1d.mooon.district.SearchRequest\x1a\x1e.mooon.district.SearchResponseB3Z1github.com/eyjian/mooon-district/district/rpc;rpcb\x06proto3"
+
+var (
+	file_district_proto_rawDescOnce sync.Once
+	file_district_proto_rawDescData []byte
+)
+
+func file_district_proto_rawDescGZIP() []byte {
+	file_district_proto_rawDescOnce.Do(func() {
+		file_district_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_district_proto_rawDesc), len(file_district_proto_rawDesc)))
+	})
+	return file_district_proto_rawDescData
+}
+
+var file_district_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_district_proto_goTypes = []any{
+	(*Province)(nil),             // 0: mooon.district.Province
+	(*City)(nil),                 // 1: mooon.district.City
+	(*County)(nil),               // 2: mooon.district.County
+	(*District)(nil),             // 3: mooon.district.District
+	(*GetByCodeRequest)(nil),     // 4: mooon.district.GetByCodeRequest
+	(*GetByNameRequest)(nil),     // 5: mooon.district.GetByNameRequest
+	(*ListChildrenRequest)(nil),  // 6: mooon.district.ListChildrenRequest
+	(*ListChildrenResponse)(nil), // 7: mooon.district.ListChildrenResponse
+	(*BatchResolveRequest)(nil),  // 8: mooon.district.BatchResolveRequest
+	(*ResolveResult)(nil),        // 9: mooon.district.ResolveResult
+	(*BatchResolveResponse)(nil), // 10: mooon.district.BatchResolveResponse
+	(*SearchRequest)(nil),        // 11: mooon.district.SearchRequest
+	(*SearchResult)(nil),         // 12: mooon.district.SearchResult
+	(*SearchResponse)(nil),       // 13: mooon.district.SearchResponse
+}
+var file_district_proto_depIdxs = []int32{
+	0,  // 0: mooon.district.District.province:type_name -> mooon.district.Province
+	1,  // 1: mooon.district.District.city:type_name -> mooon.district.City
+	2,  // 2: mooon.district.District.county:type_name -> mooon.district.County
+	3,  // 3: mooon.district.ListChildrenResponse.districts:type_name -> mooon.district.District
+	3,  // 4: mooon.district.ResolveResult.district:type_name -> mooon.district.District
+	9,  // 5: mooon.district.BatchResolveResponse.results:type_name -> mooon.district.ResolveResult
+	3,  // 6: mooon.district.SearchResult.district:type_name -> mooon.district.District
+	12, // 7: mooon.district.SearchResponse.results:type_name -> mooon.district.SearchResult
+	4,  // 8: mooon.district.DistrictService.GetByCode:input_type -> mooon.district.GetByCodeRequest
+	5,  // 9: mooon.district.DistrictService.GetByName:input_type -> mooon.district.GetByNameRequest
+	6,  // 10: mooon.district.DistrictService.ListChildren:input_type -> mooon.district.ListChildrenRequest
+	8,  // 11: mooon.district.DistrictService.BatchResolve:input_type -> mooon.district.BatchResolveRequest
+	11, // 12: mooon.district.DistrictService.Search:input_type -> mooon.district.SearchRequest
+	3,  // 13: mooon.district.DistrictService.GetByCode:output_type -> mooon.district.District
+	3,  // 14: mooon.district.DistrictService.GetByName:output_type -> mooon.district.District
+	7,  // 15: mooon.district.DistrictService.ListChildren:output_type -> mooon.district.ListChildrenResponse
+	10, // 16: mooon.district.DistrictService.BatchResolve:output_type -> mooon.district.BatchResolveResponse
+	13, // 17: mooon.district.DistrictService.Search:output_type -> mooon.district.SearchResponse
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_district_proto_init() }
+func file_district_proto_init() {
+	if File_district_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_district_proto_rawDesc), len(file_district_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_district_proto_goTypes,
+		DependencyIndexes: file_district_proto_depIdxs,
+		MessageInfos:      file_district_proto_msgTypes,
+	}.Build()
+	File_district_proto = out.File
+	file_district_proto_goTypes = nil
+	file_district_proto_depIdxs = nil
+}
@@ -0,0 +1,280 @@
+// 行政区查询服务的接口定义，Go 的代码由 protoc-gen-go 和 protoc-gen-go-grpc 生成：
+// protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative district.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: district.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DistrictService_GetByCode_FullMethodName    = "/mooon.district.DistrictService/GetByCode"
+	DistrictService_GetByName_FullMethodName    = "/mooon.district.DistrictService/GetByName"
+	DistrictService_ListChildren_FullMethodName = "/mooon.district.DistrictService/ListChildren"
+	DistrictService_BatchResolve_FullMethodName = "/mooon.district.DistrictService/BatchResolve"
+	DistrictService_Search_FullMethodName       = "/mooon.district.DistrictService/Search"
+)
+
+// DistrictServiceClient is the client API for DistrictService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DistrictService 行政区查询服务，找不到时 GetByCode 和 GetByName 返回 NOT_FOUND
+type DistrictServiceClient interface {
+	GetByCode(ctx context.Context, in *GetByCodeRequest, opts ...grpc.CallOption) (*District, error)
+	GetByName(ctx context.Context, in *GetByNameRequest, opts ...grpc.CallOption) (*District, error)
+	ListChildren(ctx context.Context, in *ListChildrenRequest, opts ...grpc.CallOption) (*ListChildrenResponse, error)
+	BatchResolve(ctx context.Context, in *BatchResolveRequest, opts ...grpc.CallOption) (*BatchResolveResponse, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+}
+
+type districtServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDistrictServiceClient(cc grpc.ClientConnInterface) DistrictServiceClient {
+	return &districtServiceClient{cc}
+}
+
+func (c *districtServiceClient) GetByCode(ctx context.Context, in *GetByCodeRequest, opts ...grpc.CallOption) (*District, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(District)
+	err := c.cc.Invoke(ctx, DistrictService_GetByCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *districtServiceClient) GetByName(ctx context.Context, in *GetByNameRequest, opts ...grpc.CallOption) (*District, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(District)
+	err := c.cc.Invoke(ctx, DistrictService_GetByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *districtServiceClient) ListChildren(ctx context.Context, in *ListChildrenRequest, opts ...grpc.CallOption) (*ListChildrenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChildrenResponse)
+	err := c.cc.Invoke(ctx, DistrictService_ListChildren_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *districtServiceClient) BatchResolve(ctx context.Context, in *BatchResolveRequest, opts ...grpc.CallOption) (*BatchResolveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchResolveResponse)
+	err := c.cc.Invoke(ctx, DistrictService_BatchResolve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *districtServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, DistrictService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DistrictServiceServer is the server API for DistrictService service.
+// All implementations must embed UnimplementedDistrictServiceServer
+// for forward compatibility.
+//
+// DistrictService 行政区查询服务，找不到时 GetByCode 和 GetByName 返回 NOT_FOUND
+type DistrictServiceServer interface {
+	GetByCode(context.Context, *GetByCodeRequest) (*District, error)
+	GetByName(context.Context, *GetByNameRequest) (*District, error)
+	ListChildren(context.Context, *ListChildrenRequest) (*ListChildrenResponse, error)
+	BatchResolve(context.Context, *BatchResolveRequest) (*BatchResolveResponse, error)
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	mustEmbedUnimplementedDistrictServiceServer()
+}
+
+// UnimplementedDistrictServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDistrictServiceServer struct{}
+
+func (UnimplementedDistrictServiceServer) GetByCode(context.Context, *GetByCodeRequest) (*District, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByCode not implemented")
+}
+func (UnimplementedDistrictServiceServer) GetByName(context.Context, *GetByNameRequest) (*District, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByName not implemented")
+}
+func (UnimplementedDistrictServiceServer) ListChildren(context.Context, *ListChildrenRequest) (*ListChildrenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChildren not implemented")
+}
+func (UnimplementedDistrictServiceServer) BatchResolve(context.Context, *BatchResolveRequest) (*BatchResolveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchResolve not implemented")
+}
+func (UnimplementedDistrictServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedDistrictServiceServer) mustEmbedUnimplementedDistrictServiceServer() {}
+func (UnimplementedDistrictServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeDistrictServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DistrictServiceServer will
+// result in compilation errors.
+type UnsafeDistrictServiceServer interface {
+	mustEmbedUnimplementedDistrictServiceServer()
+}
+
+func RegisterDistrictServiceServer(s grpc.ServiceRegistrar, srv DistrictServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDistrictServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DistrictService_ServiceDesc, srv)
+}
+
+func _DistrictService_GetByCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DistrictServiceServer).GetByCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DistrictService_GetByCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DistrictServiceServer).GetByCode(ctx, req.(*GetByCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DistrictService_GetByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DistrictServiceServer).GetByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DistrictService_GetByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DistrictServiceServer).GetByName(ctx, req.(*GetByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DistrictService_ListChildren_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChildrenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DistrictServiceServer).ListChildren(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DistrictService_ListChildren_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DistrictServiceServer).ListChildren(ctx, req.(*ListChildrenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DistrictService_BatchResolve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchResolveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DistrictServiceServer).BatchResolve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DistrictService_BatchResolve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DistrictServiceServer).BatchResolve(ctx, req.(*BatchResolveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DistrictService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DistrictServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DistrictService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DistrictServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DistrictService_ServiceDesc is the grpc.ServiceDesc for DistrictService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DistrictService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mooon.district.DistrictService",
+	HandlerType: (*DistrictServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetByCode",
+			Handler:    _DistrictService_GetByCode_Handler,
+		},
+		{
+			MethodName: "GetByName",
+			Handler:    _DistrictService_GetByName_Handler,
+		},
+		{
+			MethodName: "ListChildren",
+			Handler:    _DistrictService_ListChildren_Handler,
+		},
+		{
+			MethodName: "BatchResolve",
+			Handler:    _DistrictService_BatchResolve_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _DistrictService_Search_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "district.proto",
+}
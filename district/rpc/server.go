@@ -0,0 +1,223 @@
+// Package rpc 行政区查询的 gRPC 服务，接口定义见 district.proto ，
+// district.pb.go 和 district_grpc.pb.go 为生成的代码，不要修改
+package rpc
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/eyjian/mooon-district/district"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server DistrictService 的实现，查询基于 district.Resolver（Query 或 MemoryQuery）
+type Server struct {
+	UnimplementedDistrictServiceServer
+
+	resolver district.Resolver
+	searcher *district.Searcher
+}
+
+// NewServer 新建 DistrictService 的实现，searcher 可为 nil ，为 nil 时 Search 返回 UNIMPLEMENTED
+func NewServer(resolver district.Resolver, searcher *district.Searcher) *Server {
+	return &Server{
+		resolver: resolver,
+		searcher: searcher,
+	}
+}
+
+// NewMemoryServer 新建基于内存数据的 DistrictService 的实现，不依赖数据库
+func NewMemoryServer(table *district.Table) *Server {
+	return NewServer(district.NewMemoryQuery(table), district.NewSearcher(table))
+}
+
+// GetByCode 通过 6 位行政区代码取得行政区
+func (s *Server) GetByCode(ctx context.Context, req *GetByCodeRequest) (*District, error) {
+	d, err := s.findCode(ctx, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, status.Errorf(codes.NotFound, "district %d not found", req.GetCode())
+	}
+	return d, nil
+}
+
+// GetByName 通过斜杠分隔的行政区名取得行政区
+func (s *Server) GetByName(ctx context.Context, req *GetByNameRequest) (*District, error) {
+	d, err := s.findName(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, status.Errorf(codes.NotFound, "district %s not found", req.GetName())
+	}
+	return d, nil
+}
+
+// ListChildren 取得下一级行政区列表，code 为 0 时为所有省级行政区
+func (s *Server) ListChildren(ctx context.Context, req *ListChildrenRequest) (*ListChildrenResponse, error) {
+	code := &district.Code{}
+	if req.GetCode() != 0 {
+		d, err := s.GetByCode(ctx, &GetByCodeRequest{Code: req.GetCode()})
+		if err != nil {
+			return nil, err
+		}
+		code = d.code()
+	}
+
+	rows, err := s.resolver.GetChildren(ctx, code)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get children of %d error: %s", req.GetCode(), err.Error())
+	}
+	resp := &ListChildrenResponse{Districts: make([]*District, 0, len(rows))}
+	for _, row := range rows {
+		resp.Districts = append(resp.Districts, newDistrict(
+			&district.Code{ProvinceCode: row.ProvinceCode, CityCode: row.CityCode, CountyCode: row.CountyCode},
+			&district.Name{ProvinceName: row.ProvinceName, CityName: row.CityName, CountyName: row.CountyName}))
+	}
+	return resp, nil
+}
+
+// BatchResolve 批量解析 6 位行政区代码或斜杠分隔的行政区名，结果同输入一一对应
+func (s *Server) BatchResolve(ctx context.Context, req *BatchResolveRequest) (*BatchResolveResponse, error) {
+	resp := &BatchResolveResponse{Results: make([]*ResolveResult, 0, len(req.GetInputs()))}
+	for _, input := range req.GetInputs() {
+		var d *District
+		var err error
+		if code, ok := parseCode(input); ok {
+			d, err = s.findCode(ctx, code)
+		} else {
+			d, err = s.findName(ctx, input)
+		}
+		// 单个输入不合法不影响其它输入
+		if err != nil && status.Code(err) != codes.InvalidArgument {
+			return nil, err
+		}
+		resp.Results = append(resp.Results, &ResolveResult{Input: input, Found: d != nil, District: d})
+	}
+	return resp, nil
+}
+
+// Search 按中文、全拼或拼音首字母搜索行政区
+func (s *Server) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	if s.searcher == nil {
+		return nil, status.Errorf(codes.Unimplemented, "search is not supported without the district table")
+	}
+
+	results := s.searcher.Search(req.GetKeyword(), int(req.GetLimit()))
+	resp := &SearchResponse{Results: make([]*SearchResult, 0, len(results))}
+	for i := range results {
+		resp.Results = append(resp.Results, &SearchResult{
+			District: newDistrict(&results[i].Code, &results[i].Name),
+			Score:    results[i].Score,
+		})
+	}
+	return resp, nil
+}
+
+// findCode 通过 6 位行政区代码取得行政区，不存在时返回 nil
+func (s *Server) findCode(ctx context.Context, code uint32) (*District, error) {
+	if code < 100000 || code > 999999 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid district code: %d", code)
+	}
+
+	for _, c := range candidateCodes(code) {
+		name, err := s.resolver.GetDistrictName(ctx, c)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "get district name of %d error: %s", code, err.Error())
+		}
+		if name != nil {
+			return newDistrict(c, name), nil
+		}
+	}
+	return nil, nil
+}
+
+// findName 通过斜杠分隔的行政区名取得行政区，不存在时返回 nil
+func (s *Server) findName(ctx context.Context, name string) (*District, error) {
+	if len(name) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "district name is empty")
+	}
+
+	code, err := s.resolver.GetDistrictCode(ctx, district.ParseName(name))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get district code of %s error: %s", name, err.Error())
+	}
+	if code == nil {
+		return nil, nil
+	}
+
+	// 简名时取得完整的行政区名
+	fullName, err := s.resolver.GetDistrictName(ctx, code)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get district name of %s error: %s", name, err.Error())
+	}
+	if fullName == nil {
+		return nil, nil
+	}
+	return newDistrict(code, fullName), nil
+}
+
+// candidateCodes 6 位行政区代码可能对应的完整行政区代码，
+// 县级代码也可能是直辖市的区县或省直辖县级市，它们在市级的位置，如：{110000, 110108, 0}
+func candidateCodes(code uint32) []*district.Code {
+	provinceCode := code / 10000 * 10000
+	cityCode := code / 100 * 100
+	switch {
+	case district.IsProvinceDistrictCode(code):
+		return []*district.Code{{ProvinceCode: code}}
+	case district.IsCityDistrictCode(code):
+		return []*district.Code{{ProvinceCode: provinceCode, CityCode: code}}
+	default:
+		return []*district.Code{
+			{ProvinceCode: provinceCode, CityCode: cityCode, CountyCode: code},
+			{ProvinceCode: provinceCode, CityCode: code},
+		}
+	}
+}
+
+// parseCode 输入是否为 6 位行政区代码
+func parseCode(input string) (uint32, bool) {
+	if len(input) != 6 {
+		return 0, false
+	}
+	code, err := strconv.ParseUint(input, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(code), true
+}
+
+// newDistrict 由行政区代码和行政区名构造 District
+func newDistrict(code *district.Code, name *district.Name) *District {
+	d := &District{
+		Province: &Province{Code: code.ProvinceCode, Name: name.ProvinceName},
+		Level:    1,
+		Path:     name.ProvinceName,
+	}
+	if code.CityCode != 0 {
+		d.City = &City{Code: code.CityCode, Name: name.CityName}
+		d.Path += "/" + name.CityName
+		d.Level = 2
+		// 省直辖县级市为县级
+		if district.IsCountyDistrictCode(code.CityCode) && !district.IsMunicipalityCode(code.CityCode) {
+			d.Level = 3
+		}
+	}
+	if code.CountyCode != 0 {
+		d.County = &County{Code: code.CountyCode, Name: name.CountyName}
+		d.Path += "/" + name.CountyName
+		d.Level = 3
+	}
+	return d
+}
+
+// code 取得 District 的行政区代码
+func (d *District) code() *district.Code {
+	code := &district.Code{ProvinceCode: d.GetProvince().GetCode()}
+	code.CityCode = d.GetCity().GetCode()
+	code.CountyCode = d.GetCounty().GetCode()
+	return code
+}
@@ -0,0 +1,100 @@
+// Package rpc
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/eyjian/mooon-district/district/dataset"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(t *testing.T) DistrictServiceClient {
+	table, err := dataset.ByYear(2023)
+	if err != nil {
+		t.Fatalf("ByYear error: %s\n", err.Error())
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	RegisterDistrictServiceServer(server, NewMemoryServer(table))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient error: %s\n", err.Error())
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return NewDistrictServiceClient(conn)
+}
+
+// go test -v -run="TestDistrictService$"
+func TestDistrictService(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	cases := map[uint32]struct {
+		path  string
+		level uint32
+	}{
+		440402: {"广东省/珠海市/香洲区", 3},
+		440400: {"广东省/珠海市", 2},
+		440000: {"广东省", 1},
+		110108: {"北京市/海淀区", 2},
+		419001: {"河南省/济源市", 3},
+	}
+	for code, expect := range cases {
+		d, err := client.GetByCode(ctx, &GetByCodeRequest{Code: code})
+		if err != nil {
+			t.Errorf("GetByCode(%d) error: %s\n", code, err.Error())
+			continue
+		}
+		if d.GetPath() != expect.path || d.GetLevel() != expect.level {
+			t.Errorf("GetByCode(%d): %s %d\n", code, d.GetPath(), d.GetLevel())
+		}
+	}
+	if _, err := client.GetByCode(ctx, &GetByCodeRequest{Code: 440499}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetByCode(440499): %v\n", err)
+	}
+	if _, err := client.GetByCode(ctx, &GetByCodeRequest{Code: 44}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetByCode(44): %v\n", err)
+	}
+
+	d, err := client.GetByName(ctx, &GetByNameRequest{Name: "广东/珠海/香洲"})
+	if err != nil || d.GetCounty().GetCode() != 440402 || d.GetPath() != "广东省/珠海市/香洲区" {
+		t.Errorf("GetByName(广东/珠海/香洲): %v, %v\n", d, err)
+	}
+
+	children, err := client.ListChildren(ctx, &ListChildrenRequest{Code: 440400})
+	if err != nil || len(children.GetDistricts()) != 3 {
+		t.Errorf("ListChildren(440400): %v, %v\n", children, err)
+	}
+	children, err = client.ListChildren(ctx, &ListChildrenRequest{})
+	if err != nil || len(children.GetDistricts()) != 34 {
+		t.Errorf("ListChildren(0): %d, %v\n", len(children.GetDistricts()), err)
+	}
+
+	batch, err := client.BatchResolve(ctx, &BatchResolveRequest{Inputs: []string{"440402", "河南/济源", "火星", ""}})
+	if err != nil {
+		t.Fatalf("BatchResolve error: %s\n", err.Error())
+	}
+	found := []bool{true, true, false, false}
+	for i, result := range batch.GetResults() {
+		if result.GetFound() != found[i] {
+			t.Errorf("BatchResolve(%s): %v\n", result.GetInput(), result)
+		}
+	}
+
+	search, err := client.Search(ctx, &SearchRequest{Keyword: "zhuhai", Limit: 1})
+	if err != nil || len(search.GetResults()) != 1 || search.GetResults()[0].GetDistrict().GetPath() != "广东省/珠海市" {
+		t.Errorf("Search(zhuhai): %v, %v\n", search, err)
+	}
+}
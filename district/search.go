@@ -0,0 +1,196 @@
+// Package district
+package district
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SearchResult 搜索结果
+type SearchResult struct {
+	Code  Code    `json:"code"`
+	Name  Name    `json:"name"`
+	Level uint32  `json:"level"`
+	Path  string  `json:"path"`  // 完整路径，如：广东省/珠海市/香洲区
+	Score float64 `json:"score"` // 匹配得分，取值 0 到 1 ，越大越匹配
+}
+
+// Searcher 行政区搜索器，支持全拼、拼音首字母、中文前缀和编辑距离（容错）匹配
+type Searcher struct {
+	entries []searchEntry
+}
+
+type searchEntry struct {
+	result        SearchResult
+	name          []rune
+	shortName     []rune
+	pinyin        string // 全拼，如：zhuhaishi
+	shortPinyin   string // 简名的全拼，如：zhuhai
+	initials      string // 拼音首字母，如：zhs
+	shortInitials string // 简名的拼音首字母，如：zh
+}
+
+// NewSearcher 新建搜索器
+func NewSearcher(table *Table) *Searcher {
+	s := &Searcher{entries: make([]searchEntry, 0)}
+
+	for _, row := range table.Rows() {
+		result := SearchResult{
+			Code:  Code{ProvinceCode: row.ProvinceCode, CityCode: row.CityCode, CountyCode: row.CountyCode},
+			Name:  Name{ProvinceName: row.ProvinceName, CityName: row.CityName, CountyName: row.CountyName},
+			Level: row.Level,
+		}
+		name := row.ProvinceName
+		result.Path = row.ProvinceName
+		if row.CityCode != 0 {
+			name = row.CityName
+			result.Path += "/" + row.CityName
+		}
+		if row.CountyCode != 0 {
+			name = row.CountyName
+			result.Path += "/" + row.CountyName
+		}
+
+		shortName := shortDistrictName(name)
+		s.entries = append(s.entries, searchEntry{
+			result:        result,
+			name:          []rune(name),
+			shortName:     []rune(shortName),
+			pinyin:        strings.Join(Pinyin(name), ""),
+			shortPinyin:   strings.Join(Pinyin(shortName), ""),
+			initials:      PinyinInitials(name),
+			shortInitials: PinyinInitials(shortName),
+		})
+	}
+
+	return s
+}
+
+// Search 搜索行政区，keyword 可为中文（如：珠、珠海、珠海巿）、全拼（如：zhuhai）或拼音首字母（如：zh），
+// 返回按得分从高到低排序的最多 limit 个结果，limit 小于等于 0 时不限制
+func (s *Searcher) Search(keyword string, limit int) []SearchResult {
+	keyword = strings.ToLower(strings.Join(strings.Fields(keyword), ""))
+	results := make([]SearchResult, 0)
+	if len(keyword) == 0 {
+		return results
+	}
+
+	chinese := false
+	for _, r := range keyword {
+		if r > unicode.MaxASCII {
+			chinese = true
+			break
+		}
+	}
+
+	for i := range s.entries {
+		entry := &s.entries[i]
+		var score float64
+		if chinese {
+			score = entry.scoreChinese([]rune(keyword))
+		} else {
+			score = entry.scorePinyin(keyword)
+		}
+		if score > 0 {
+			result := entry.result
+			result.Score = score
+			results = append(results, result)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Level < results[j].Level
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// scoreChinese 中文关键词的得分
+func (e *searchEntry) scoreChinese(keyword []rune) float64 {
+	name, shortName := string(e.name), string(e.shortName)
+	k := string(keyword)
+
+	switch {
+	case k == name:
+		return 1.0
+	case k == shortName:
+		return 0.95
+	case strings.HasPrefix(name, k):
+		return 0.7 + 0.2*float64(len(keyword))/float64(len(e.name))
+	case strings.Contains(name, k):
+		return 0.5 + 0.2*float64(len(keyword))/float64(len(e.name))
+	}
+
+	// 容错，如：珠海巿 => 珠海市，太短的关键词容错会匹配出过多无关的结果
+	if len(keyword) >= 3 {
+		return scoreEditDistance(keyword, e.name, e.shortName)
+	}
+	return 0
+}
+
+// scorePinyin 拼音关键词的得分
+func (e *searchEntry) scorePinyin(keyword string) float64 {
+	switch {
+	case keyword == e.pinyin || keyword == e.shortPinyin:
+		return 0.9
+	case keyword == e.initials || keyword == e.shortInitials:
+		return 0.85
+	case strings.HasPrefix(e.pinyin, keyword):
+		return 0.6 + 0.2*float64(len(keyword))/float64(len(e.pinyin))
+	case strings.HasPrefix(e.initials, keyword):
+		return 0.5 + 0.2*float64(len(keyword))/float64(len(e.initials))
+	}
+
+	// 容错，如：zhuhia => zhuhai
+	if len(keyword) >= 4 {
+		return scoreEditDistance([]rune(keyword), []rune(e.pinyin), []rune(e.shortPinyin))
+	}
+	return 0
+}
+
+// scoreEditDistance 编辑距离的得分，每 3 个字符最多容许 1 处错误，超出返回 0
+func scoreEditDistance(keyword []rune, candidates ...[]rune) float64 {
+	best := 0.0
+	for _, candidate := range candidates {
+		maxDistance := len(candidate) / 3
+		if maxDistance == 0 {
+			maxDistance = 1
+		}
+		distance := levenshtein(keyword, candidate)
+		if distance > maxDistance || distance >= len(candidate) {
+			continue
+		}
+		if score := 0.5 * (1 - float64(distance)/float64(len(candidate))); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// levenshtein 计算两个字符串的编辑距离
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,61 @@
+// Package district
+package district
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// go test -v -run="TestPinyin$"
+func TestPinyin(t *testing.T) {
+	cases := map[string]string{
+		"珠海市":   "zhu hai shi",
+		"重庆市":   "chong qing shi",
+		"厦门市":   "xia men shi",
+		"六安市":   "lu an shi",
+		"成都市":   "cheng du shi",
+		"西藏自治区": "xi zang zi zhi qu",
+	}
+	for name, expect := range cases {
+		if syllables := strings.Join(Pinyin(name), " "); syllables != expect {
+			t.Errorf("Pinyin(%s): %s, expect %s\n", name, syllables, expect)
+		}
+	}
+	if initials := PinyinInitials("珠海市"); initials != "zhs" {
+		t.Errorf("PinyinInitials(珠海市): %s\n", initials)
+	}
+}
+
+// go test -v -run="TestSearch$"
+func TestSearch(t *testing.T) {
+	table, err := LoadDistrict(context.Background(), "../district-2023.csv")
+	if err != nil {
+		t.Fatalf("LoadDistrict error: %s\n", err.Error())
+	}
+	searcher := NewSearcher(table)
+
+	for _, keyword := range []string{"zhuhai", "ZhuHai", "zh", "珠", "珠海", "珠海巿", "zhuhia"} {
+		results := searcher.Search(keyword, 10)
+		found := false
+		for _, result := range results {
+			if result.Path == "广东省/珠海市" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Search(%s): 珠海市 not found in %v\n", keyword, results)
+		} else {
+			t.Logf("Search(%s): %s %.2f\n", keyword, results[0].Path, results[0].Score)
+		}
+	}
+
+	results := searcher.Search("珠海", 1)
+	if len(results) != 1 || results[0].Code.CityCode != 440400 {
+		t.Errorf("Search(珠海): %v\n", results)
+	}
+	if results = searcher.Search("火星", 10); len(results) != 0 {
+		t.Errorf("Search(火星): %v\n", results)
+	}
+}
@@ -0,0 +1,202 @@
+// Package district
+package district
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SuccessionKind 行政区代码的继承类型
+type SuccessionKind string
+
+const (
+	SuccessionRecode SuccessionKind = "recode" // 一对一，如撤县设市：540422,米林县 => 540481,米林市
+	SuccessionMerge  SuccessionKind = "merge"  // 多对一，多个行政区合并为一个
+	SuccessionSplit  SuccessionKind = "split"  // 一对多，一个行政区拆分为多个
+)
+
+// Succession 一条行政区代码的继承关系
+type Succession struct {
+	OldCode uint32         `json:"old_code"`
+	NewCode uint32         `json:"new_code"`
+	Kind    SuccessionKind `json:"kind"`
+	Date    string         `json:"date,omitempty"` // 生效日期，如：2023-04-28
+	Note    string         `json:"note,omitempty"`
+}
+
+// SuccessionRegistry 行政区代码继承关系登记表，用于将已撤销的代码解析为现行代码，或反向解析为历史代码
+type SuccessionRegistry struct {
+	forward  map[uint32][]Succession // 旧代码 => 继承关系
+	backward map[uint32][]Succession // 新代码 => 继承关系
+}
+
+// NewSuccessionRegistry 新建空的继承关系登记表
+func NewSuccessionRegistry() *SuccessionRegistry {
+	return &SuccessionRegistry{
+		forward:  make(map[uint32][]Succession),
+		backward: make(map[uint32][]Succession),
+	}
+}
+
+// LoadSuccession 从映射文件加载继承关系，每行格式为：旧代码,新代码[,类型[,生效日期[,备注]]]，
+// 类型缺省为 recode ，首行为标题时跳过，以 # 开头的行为注释
+func LoadSuccession(ctx context.Context, filepath string) (*SuccessionRegistry, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return LoadSuccessionFromReader(ctx, file)
+}
+
+// LoadSuccessionFromReader 从 io.Reader 加载继承关系，格式同 LoadSuccession
+func LoadSuccessionFromReader(ctx context.Context, r io.Reader) (*SuccessionRegistry, error) {
+	registry := NewSuccessionRegistry()
+	reader, err := newBufferedReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	lineNo := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		lineNo = lineNo + 1
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			if len(line) == 0 {
+				break
+			}
+		}
+
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.Split(line, ",")
+		if len(parts) < 2 || len(parts) > 5 {
+			return nil, fmt.Errorf("invalid row format: (%d) %s, expected format: OldCode,NewCode[,Kind[,Date[,Note]]]", lineNo, line)
+		}
+		oldCode, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 32)
+		if err != nil {
+			if lineNo == 1 {
+				continue // 标题行
+			}
+			return nil, fmt.Errorf("invalid old code: (%d) %s", lineNo, line)
+		}
+		newCode, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid new code: (%d) %s", lineNo, line)
+		}
+
+		succession := Succession{
+			OldCode: uint32(oldCode),
+			NewCode: uint32(newCode),
+			Kind:    SuccessionRecode,
+		}
+		if len(parts) > 2 && len(strings.TrimSpace(parts[2])) > 0 {
+			succession.Kind = SuccessionKind(strings.TrimSpace(parts[2]))
+		}
+		if len(parts) > 3 {
+			succession.Date = strings.TrimSpace(parts[3])
+		}
+		if len(parts) > 4 {
+			succession.Note = strings.TrimSpace(parts[4])
+		}
+		registry.Add(succession)
+	}
+
+	return registry, nil
+}
+
+// Add 登记一条继承关系，重复登记的忽略
+func (r *SuccessionRegistry) Add(succession Succession) {
+	for _, s := range r.forward[succession.OldCode] {
+		if s.NewCode == succession.NewCode {
+			return
+		}
+	}
+	r.forward[succession.OldCode] = append(r.forward[succession.OldCode], succession)
+	r.backward[succession.NewCode] = append(r.backward[succession.NewCode], succession)
+}
+
+// AddChangeSet 从数据集的变更集中登记继承关系（只有变更代码的才登记），
+// date 为新数据集的生效日期，可为空
+func (r *SuccessionRegistry) AddChangeSet(changeSet *ChangeSet, date string) {
+	for _, change := range changeSet.Changes {
+		if change.Kind != ChangeRecoded {
+			continue
+		}
+		r.Add(Succession{
+			OldCode: change.OldCode,
+			NewCode: change.Code,
+			Kind:    SuccessionRecode,
+			Date:    date,
+			Note:    fmt.Sprintf("%s => %s", change.OldName, change.Name),
+		})
+	}
+}
+
+// Len 登记的继承关系数
+func (r *SuccessionRegistry) Len() int {
+	count := 0
+	for _, successions := range r.forward {
+		count += len(successions)
+	}
+	return count
+}
+
+// ResolveCurrent 沿着更名、合并和拆分将代码解析为现行代码，同时返回经过的继承关系链；
+// 没有登记继承关系的代码原样返回，拆分的会返回多个现行代码
+func (r *SuccessionRegistry) ResolveCurrent(code uint32) ([]uint32, []Succession) {
+	return resolveSuccession(code, r.forward, func(s *Succession) uint32 { return s.NewCode })
+}
+
+// ResolveHistorical 反向解析，取得代码最早的历史代码，用于按旧年度的数据出报表
+func (r *SuccessionRegistry) ResolveHistorical(code uint32) ([]uint32, []Succession) {
+	return resolveSuccession(code, r.backward, func(s *Succession) uint32 { return s.OldCode })
+}
+
+// resolveSuccession 广度优先遍历继承关系，直到没有后继的代码，已访问的代码不再重复访问以防环
+func resolveSuccession(code uint32, table map[uint32][]Succession, next func(s *Succession) uint32) ([]uint32, []Succession) {
+	results := make([]uint32, 0)
+	chain := make([]Succession, 0)
+	visited := map[uint32]bool{code: true}
+	queue := []uint32{code}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		successions := table[current]
+		if len(successions) == 0 {
+			results = append(results, current)
+			continue
+		}
+		for i := range successions {
+			chain = append(chain, successions[i])
+			nextCode := next(&successions[i])
+			if !visited[nextCode] {
+				visited[nextCode] = true
+				queue = append(queue, nextCode)
+			}
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i] < results[j]
+	})
+	return results, chain
+}
@@ -0,0 +1,60 @@
+// Package district
+package district
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// go test -v -run="TestResolveCurrent$"
+func TestResolveCurrent(t *testing.T) {
+	data := `旧代码,新代码,类型,生效日期,备注
+# 撤县设市
+540422,540481,recode,2023-01-01,米林县 => 米林市
+# 假设的合并和拆分
+100001,100003,merge
+100002,100003,merge
+100003,100004,split
+100003,100005,split`
+
+	registry, err := LoadSuccessionFromReader(context.Background(), strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadSuccessionFromReader error: %s\n", err.Error())
+	}
+	if registry.Len() != 5 {
+		t.Errorf("Len: %d\n", registry.Len())
+	}
+
+	codes, chain := registry.ResolveCurrent(540422)
+	if len(codes) != 1 || codes[0] != 540481 || len(chain) != 1 || chain[0].Date != "2023-01-01" {
+		t.Errorf("ResolveCurrent(540422): %v %v\n", codes, chain)
+	}
+
+	codes, chain = registry.ResolveCurrent(100001)
+	if len(codes) != 2 || codes[0] != 100004 || codes[1] != 100005 || len(chain) != 3 {
+		t.Errorf("ResolveCurrent(100001): %v %v\n", codes, chain)
+	}
+
+	codes, chain = registry.ResolveHistorical(100005)
+	if len(codes) != 2 || codes[0] != 100001 || codes[1] != 100002 || len(chain) != 3 {
+		t.Errorf("ResolveHistorical(100005): %v %v\n", codes, chain)
+	}
+
+	// 没有登记的原样返回
+	codes, chain = registry.ResolveCurrent(440402)
+	if len(codes) != 1 || codes[0] != 440402 || len(chain) != 0 {
+		t.Errorf("ResolveCurrent(440402): %v %v\n", codes, chain)
+	}
+
+	// 从变更集登记
+	ctx := context.Background()
+	oldTable, _ := LoadDistrictFromReader(ctx, strings.NewReader(testOldDistrictData))
+	newTable, _ := LoadDistrictFromReader(ctx, strings.NewReader(testNewDistrictData))
+	registry = NewSuccessionRegistry()
+	registry.AddChangeSet(Diff(oldTable, newTable), "2023-01-01")
+	codes, _ = registry.ResolveCurrent(540422)
+	if len(codes) != 1 || codes[0] != 540481 {
+		t.Errorf("ResolveCurrent(540422) from change set: %v\n", codes)
+	}
+}
@@ -0,0 +1,191 @@
+// Package district
+package district
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Township 乡级行政区（乡/镇/街道），代码为国家统计局统计用区划代码的前 9 位
+type Township struct {
+	Code         uint64             `json:"code"`   // 乡级代码，如：440402001
+	Name         string             `json:"name"`   // 行政区名称
+	Level        uint32             `json:"level"`  // 行政区级别，固定为 4
+	Parent       uint32             `json:"parent"` // 县级（直辖市的区县、不设区的市为市级）行政区代码
+	VillageTable map[uint64]Village `json:"-"`
+	Villages     []Village          `json:"villages,omitempty"`
+}
+
+// Village 村级（村委会/居委会），代码为国家统计局 12 位统计用区划代码
+type Village struct {
+	Code           uint64 `json:"code"`                       // 村级代码，如：440402001001
+	Name           string `json:"name"`                       // 名称
+	Level          uint32 `json:"level"`                      // 行政区级别，固定为 5
+	Parent         uint64 `json:"parent"`                     // 乡级代码
+	UrbanRuralCode string `json:"urban_rural_code,omitempty"` // 城乡分类代码，如：111 主城区
+}
+
+// HasTownships 是否含有乡级行政区
+func (t *Table) HasTownships() bool {
+	for _, provinceDistrict := range t.Provinces {
+		for _, cityDistrict := range provinceDistrict.Cities {
+			if len(cityDistrict.Townships) > 0 {
+				return true
+			}
+			for _, countyDistrict := range cityDistrict.Counties {
+				if len(countyDistrict.Townships) > 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// HasVillages 是否含有村级
+func (t *Table) HasVillages() bool {
+	for _, provinceDistrict := range t.Provinces {
+		for _, cityDistrict := range provinceDistrict.Cities {
+			if hasVillages(cityDistrict.Townships) {
+				return true
+			}
+			for _, countyDistrict := range cityDistrict.Counties {
+				if hasVillages(countyDistrict.Townships) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func hasVillages(townships []Township) bool {
+	for _, township := range townships {
+		if len(township.Villages) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isStatisticalLine 是否为国家统计局统计用区划代码格式的行，即代码为 9 位或 12 位
+func isStatisticalLine(line string) bool {
+	code := strings.TrimSpace(strings.SplitN(line, ",", 2)[0])
+	if len(code) != 9 && len(code) != 12 {
+		return false
+	}
+	_, err := strconv.ParseUint(code, 10, 64)
+	return err == nil
+}
+
+// parseStatisticalLine 解析国家统计局统计用区划代码格式的行，每行格式为：代码,名称 或 代码,城乡分类代码,名称，
+// 省市县三级（12 位代码的后 6 位为 0）返回同 parseLine ，乡级和村级直接加入 table 后返回 nil ，
+// 直辖市的“市辖区”、“县”和“省直辖县级行政区划”等统计用的汇总行被跳过也返回 nil
+func parseStatisticalLine(table *Table, lineNo int, line string) (*District, error) {
+	parts := strings.Split(line, ",")
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, fmt.Errorf("invalid row format: (%d) %s, expected format: StatisticalCode[,UrbanRuralCode],Name", lineNo, line)
+	}
+	codeStr := strings.TrimSpace(parts[0])
+	code, _ := strconv.ParseUint(codeStr, 10, 64)
+	name := strings.TrimSpace(parts[len(parts)-1])
+	if len(codeStr) == 9 {
+		code = code * 1000
+	}
+
+	switch {
+	case code%1000000 == 0:
+		// 省市县三级
+		districtCode := uint32(code / 1000000)
+		if IsCityDistrictCode(districtCode) && (IsMunicipalityCode(districtCode) || strings.HasSuffix(name, "直辖县级行政区划")) {
+			return nil, nil
+		}
+		if IsCountyDistrictCode(districtCode) && name == "市辖区" {
+			return nil, nil
+		}
+		return parseLine(lineNo, fmt.Sprintf("%d,%s", districtCode, name))
+
+	case code%1000 == 0:
+		// 乡级
+		townshipCode := code / 1000
+		countyCode := uint32(townshipCode / 1000)
+		townshipTable := findTownshipTable(table, countyCode)
+		if townshipTable == nil {
+			return nil, fmt.Errorf("missing parent district: (%d) %s", lineNo, line)
+		}
+		township := townshipTable[townshipCode]
+		township.Code, township.Name, township.Level, township.Parent = townshipCode, name, 4, countyCode
+		townshipTable[townshipCode] = township
+		return nil, nil
+
+	default:
+		// 村级
+		townshipCode := code / 1000
+		townshipTable := findTownshipTable(table, uint32(townshipCode/1000))
+		township, ok := townshipTable[townshipCode]
+		if !ok {
+			return nil, fmt.Errorf("missing parent township: (%d) %s", lineNo, line)
+		}
+		if township.VillageTable == nil {
+			township.VillageTable = make(map[uint64]Village)
+			townshipTable[townshipCode] = township
+		}
+		village := Village{Code: code, Name: name, Level: 5, Parent: townshipCode}
+		if len(parts) == 3 {
+			village.UrbanRuralCode = strings.TrimSpace(parts[1])
+		}
+		township.VillageTable[code] = village
+		return nil, nil
+	}
+}
+
+// findTownshipTable 取得县级行政区的乡级表，直辖市的区县、省直辖县级市和不设区的市（如：东莞市）取市级行政区的，
+// 县级行政区不存在时返回 nil
+func findTownshipTable(table *Table, countyCode uint32) map[uint64]Township {
+	provinceDistrict, ok := table.ProvinceDistrictTable[getProvinceDistrictCode(countyCode)]
+	if !ok {
+		return nil
+	}
+
+	if cityDistrict, ok := provinceDistrict.CityDistrictTable[getCityDistrictCode(countyCode)]; ok {
+		if countyDistrict, ok := cityDistrict.CountyDistrictTable[countyCode]; ok {
+			if countyDistrict.TownshipTable == nil {
+				countyDistrict.TownshipTable = make(map[uint64]Township)
+				cityDistrict.CountyDistrictTable[countyCode] = countyDistrict
+			}
+			return countyDistrict.TownshipTable
+		}
+	}
+	if cityDistrict, ok := provinceDistrict.CityDistrictTable[countyCode]; ok {
+		if cityDistrict.TownshipTable == nil {
+			cityDistrict.TownshipTable = make(map[uint64]Township)
+			provinceDistrict.CityDistrictTable[countyCode] = cityDistrict
+		}
+		return cityDistrict.TownshipTable
+	}
+	return nil
+}
+
+// perfectTownships 将乡级表和村级表展开为按代码排序的数组
+func perfectTownships(townshipTable map[uint64]Township) []Township {
+	if len(townshipTable) == 0 {
+		return nil
+	}
+
+	townships := make([]Township, 0, len(townshipTable))
+	for _, township := range townshipTable {
+		for _, village := range township.VillageTable {
+			township.Villages = append(township.Villages, village)
+		}
+		sort.Slice(township.Villages, func(i, j int) bool {
+			return township.Villages[i].Code < township.Villages[j].Code
+		})
+		townships = append(townships, township)
+	}
+	sort.Slice(townships, func(i, j int) bool {
+		return townships[i].Code < townships[j].Code
+	})
+	return townships
+}
@@ -0,0 +1,124 @@
+// Package district
+package district
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 国家统计局统计用区划代码格式
+const testStatisticalData = `统计用区划代码,名称
+110000000000,北京市
+110100000000,市辖区
+110108000000,海淀区
+110108001000,万寿路街道
+410000000000,河南省
+419000000000,省直辖县级行政区划
+419001000000,济源市
+419001001000,沁园街道
+440000000000,广东省
+440400000000,珠海市
+440402000000,香洲区
+440402001000,拱北街道
+440402001001,111,夏湾社区居委会
+440402001002,111,拱北社区居委会
+440402002,吉大街道
+441900000000,东莞市
+441900003000,东城街道`
+
+// go test -v -run="TestLoadStatistical$"
+func TestLoadStatistical(t *testing.T) {
+	table, err := LoadDistrictFromReader(context.Background(), strings.NewReader(testStatisticalData))
+	if err != nil {
+		t.Fatalf("LoadDistrictFromReader error: %s\n", err.Error())
+	}
+	if !table.HasTownships() || !table.HasVillages() {
+		t.Errorf("HasTownships: %v, HasVillages: %v\n", table.HasTownships(), table.HasVillages())
+	}
+
+	// 直辖市的“市辖区”和“省直辖县级行政区划”被跳过
+	if len(table.ProvinceDistrictTable[110000].CityDistrictTable) != 1 || len(table.ProvinceDistrictTable[410000].CityDistrictTable) != 1 {
+		t.Errorf("CityDistrictTable: %v, %v\n", table.ProvinceDistrictTable[110000].CityDistrictTable, table.ProvinceDistrictTable[410000].CityDistrictTable)
+	}
+
+	cases := map[uint32][]Township{
+		110108: table.Provinces[0].Cities[0].Townships,
+		419001: table.Provinces[1].Cities[0].Townships,
+		440402: table.Provinces[2].Cities[0].Counties[0].Townships,
+		441900: table.Provinces[2].Cities[1].Townships,
+	}
+	for code, townships := range cases {
+		if len(townships) == 0 || townships[0].Parent != code || townships[0].Level != 4 {
+			t.Errorf("Townships(%d): %v\n", code, townships)
+		}
+	}
+
+	townships := table.Provinces[2].Cities[0].Counties[0].Townships
+	if len(townships) != 2 || townships[0].Code != 440402001 || townships[1].Name != "吉大街道" {
+		t.Fatalf("Townships(440402): %v\n", townships)
+	}
+	villages := townships[0].Villages
+	if len(villages) != 2 || villages[0].Code != 440402001001 || villages[0].UrbanRuralCode != "111" || villages[0].Level != 5 {
+		t.Errorf("Villages(440402001): %v\n", villages)
+	}
+
+	if _, err := LoadDistrictFromReader(context.Background(), strings.NewReader("440000000000,广东省\n440402001000,拱北街道")); err == nil {
+		t.Errorf("LoadDistrictFromReader: expect error of missing parent\n")
+	}
+}
+
+// go test -v -run="TestGenerateTownship$"
+func TestGenerateTownship(t *testing.T) {
+	table, err := LoadDistrictFromReader(context.Background(), strings.NewReader(testStatisticalData))
+	if err != nil {
+		t.Fatalf("LoadDistrictFromReader error: %s\n", err.Error())
+	}
+	dir := t.TempDir()
+
+	sqlFilepath := filepath.Join(dir, "township.sql")
+	if err := GenerateSql(table, sqlFilepath, "t_dict_district", false); err != nil {
+		t.Fatalf("GenerateSql error: %s\n", err.Error())
+	}
+	data, err := os.ReadFile(sqlFilepath)
+	if err != nil {
+		t.Fatalf("ReadFile error: %s\n", err.Error())
+	}
+	for _, expect := range []string{
+		"f_township_code BIGINT UNSIGNED",
+		"(440000,440400,440402,3,'广东省','珠海市','香洲区',0,'',0,'')",
+		"(440000,440400,440402,5,'广东省','珠海市','香洲区',440402001,'拱北街道',440402001001,'夏湾社区居委会')",
+		"(110000,110108,0,4,'北京市','海淀区','',110108001,'万寿路街道',0,'')",
+	} {
+		if !strings.Contains(string(data), expect) {
+			t.Errorf("GenerateSql: %s not found\n", expect)
+		}
+	}
+
+	if err := GenerateXlsx(table, filepath.Join(dir, "township.xlsx")); err != nil {
+		t.Errorf("GenerateXlsx error: %s\n", err.Error())
+	}
+}
+
+// go test -v -run="TestMigrationTownship$"
+func TestMigrationTownship(t *testing.T) {
+	ctx := context.Background()
+	oldTable, _ := LoadDistrictFromReader(ctx, strings.NewReader(strings.Replace(testStatisticalData, "440402002,吉大街道\n", "", 1)))
+	newTable, _ := LoadDistrictFromReader(ctx, strings.NewReader(strings.Replace(testStatisticalData, "拱北社区居委会", "拱北社区", 1)))
+
+	sql := MigrationSql(oldTable, newTable, "t_dict_district")
+	t.Logf("\n%s", sql)
+
+	expects := []string{
+		"-- DELETE: 0, UPDATE: 1, INSERT: 1",
+		"UPDATE t_dict_district SET f_level=5,f_province_name='广东省',f_city_name='珠海市',f_county_name='香洲区',f_township_name='拱北街道',f_village_name='拱北社区' WHERE f_province_code=440000 AND f_city_code=440400 AND f_county_code=440402 AND f_township_code=440402001 AND f_village_code=440402001002;",
+		"INSERT INTO t_dict_district (f_province_code,f_city_code,f_county_code,f_level,f_province_name,f_city_name,f_county_name,f_township_code,f_township_name,f_village_code,f_village_name) VALUES (440000,440400,440402,4,'广东省','珠海市','香洲区',440402002,'吉大街道',0,'');",
+	}
+	for _, expect := range expects {
+		if !strings.Contains(sql, expect+"\n") {
+			t.Errorf("missing: %s\n", expect)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Package district
+package district
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// 合成代码末两位的范围，从 99 开始往下分配。6 位代码中没有 GB/T 2260 不用的县级代码段，
+// 81 至 99 为县级市的代码段，以后正式分配的代码可能和合成代码相同（如：460399），
+// 因此合成代码的类别为 KindSynthetic ，Diff 不比较合成代码的行政区，MigrationSql 将其和同代码的正式行政区视为不同的行政区
+const (
+	syntheticSuffixMax = 99
+	syntheticSuffixMin = 91
+)
+
+// LoadUncodedCodes 从补充映射文件加载无编码行政区的代码，每行格式为：行政区名称,行政区代码，
+// 如：西沙区,460301 ，首个非注释行为标题时跳过，以 # 开头的行为注释
+func LoadUncodedCodes(ctx context.Context, filepath string) (map[string]uint32, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return LoadUncodedCodesFromReader(ctx, file)
+}
+
+// LoadUncodedCodesFromReader 从 io.Reader 加载无编码行政区的代码，格式同 LoadUncodedCodes
+func LoadUncodedCodesFromReader(ctx context.Context, r io.Reader) (map[string]uint32, error) {
+	uncodedCodes := make(map[string]uint32)
+	reader, err := newBufferedReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	lineNo := 0
+	titled := false // 已过首个非注释行
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		lineNo = lineNo + 1
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			if len(line) == 0 {
+				break
+			}
+		}
+
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.Split(line, ",")
+		if len(parts) != 2 || len(strings.TrimSpace(parts[0])) == 0 {
+			return nil, fmt.Errorf("invalid row format: (%d) %s, expected format: DistrictName,DistrictCode", lineNo, line)
+		}
+		code, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 32)
+		if err != nil || code < 100000 || code > 999999 {
+			if !titled {
+				titled = true
+				continue // 标题行
+			}
+			return nil, fmt.Errorf("invalid district code: (%d) %s", lineNo, line)
+		}
+		titled = true
+		uncodedCodes[strings.TrimSpace(parts[0])] = uint32(code)
+	}
+
+	return uncodedCodes, nil
+}
+
+// uncodedName 取得无编码行（如：,西沙区）的行政区名称，不是无编码行的返回空字符串
+func uncodedName(line string) string {
+	parts := strings.Split(line, ",")
+	if len(parts) != 2 || len(strings.TrimSpace(parts[0])) != 0 {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
+
+// parseUncodedLine 为无编码行分配代码，优先取 options.UncodedCodes 中的，
+// 其次在上一行所在的市级行政区下分配合成代码（如：三沙市 460300 下的西沙区为 460399），都没有时返回 nil
+func parseUncodedLine(table *Table, lineNo int, name string, lastCode uint32, options *LoadOptions) (*District, error) {
+	if code, ok := options.UncodedCodes[name]; ok {
+		district, err := parseLine(lineNo, fmt.Sprintf("%d,%s", code, name))
+		if err != nil {
+			return nil, err
+		}
+		district.Uncoded = true
+		return district, nil
+	}
+	if !options.SyntheticCodes {
+		return nil, nil
+	}
+
+	// 合成代码只分配在市级行政区下
+	cityCode := getCityDistrictCode(lastCode)
+	if lastCode == 0 || IsProvinceDistrictCode(cityCode) {
+		return nil, fmt.Errorf("no parent city for uncoded district: (%d) ,%s", lineNo, name)
+	}
+	cityDistrict := table.ProvinceDistrictTable[getProvinceDistrictCode(cityCode)].CityDistrictTable[cityCode]
+	for suffix := uint32(syntheticSuffixMax); suffix >= syntheticSuffixMin; suffix-- {
+		code := cityCode + suffix
+		if _, ok := cityDistrict.CountyDistrictTable[code]; ok {
+			continue
+		}
+
+		district, err := parseLine(lineNo, fmt.Sprintf("%d,%s", code, name))
+		if err != nil {
+			return nil, err
+		}
+		district.Uncoded = true
+		district.Synthetic = true
+		district.Kind = KindSynthetic
+		return district, nil
+	}
+	return nil, fmt.Errorf("no synthetic code left for uncoded district: (%d) ,%s", lineNo, name)
+}
@@ -0,0 +1,84 @@
+// Package district
+package district
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// go test -v -run="TestLoadUncoded$"
+func TestLoadUncoded(t *testing.T) {
+	ctx := context.Background()
+
+	// 缺省跳过无编码的行
+	table, err := LoadDistrict(ctx, "../district-2022.csv")
+	if err != nil {
+		t.Fatalf("LoadDistrict error: %s\n", err.Error())
+	}
+	sansha := table.ProvinceDistrictTable[460000].CityDistrictTable[460300]
+	if len(sansha.CountyDistrictTable) != 0 {
+		t.Errorf("三沙市: %v\n", sansha.CountyDistrictTable)
+	}
+
+	// 合成代码
+	table, err = LoadDistrictWithOptions(ctx, "../district-2022.csv", LoadOptions{SyntheticCodes: true})
+	if err != nil {
+		t.Fatalf("LoadDistrictWithOptions error: %s\n", err.Error())
+	}
+	sansha = table.ProvinceDistrictTable[460000].CityDistrictTable[460300]
+	if county := sansha.CountyDistrictTable[460399]; county.Name != "西沙区" || !county.Uncoded || !county.Synthetic || county.Kind != KindSynthetic {
+		t.Errorf("460399: %v\n", county)
+	}
+	if county := sansha.CountyDistrictTable[460398]; county.Name != "南沙区" || !county.Uncoded || !county.Synthetic {
+		t.Errorf("460398: %v\n", county)
+	}
+
+	// 补充映射文件
+	uncodedCodes, err := LoadUncodedCodesFromReader(ctx, strings.NewReader("# 三沙市\nname,code\n西沙区,460301"))
+	if err != nil {
+		t.Fatalf("LoadUncodedCodesFromReader error: %s\n", err.Error())
+	}
+	table, err = LoadDistrictWithOptions(ctx, "../district-2022.csv", LoadOptions{UncodedCodes: uncodedCodes})
+	if err != nil {
+		t.Fatalf("LoadDistrictWithOptions error: %s\n", err.Error())
+	}
+	sansha = table.ProvinceDistrictTable[460000].CityDistrictTable[460300]
+	if county := sansha.CountyDistrictTable[460301]; county.Name != "西沙区" || !county.Uncoded || county.Synthetic {
+		t.Errorf("460301: %v\n", county)
+	}
+	if len(sansha.CountyDistrictTable) != 1 {
+		t.Errorf("三沙市: %v\n", sansha.CountyDistrictTable)
+	}
+
+	// 没有上级市级行政区
+	_, err = LoadDistrictFromReaderWithOptions(ctx, strings.NewReader("460000,海南省\n,西沙区"), LoadOptions{SyntheticCodes: true})
+	if err == nil {
+		t.Errorf("LoadDistrictFromReaderWithOptions: expect error\n")
+	}
+	if _, err := LoadUncodedCodesFromReader(ctx, strings.NewReader("西沙区,460301\n南沙区,46")); err == nil {
+		t.Errorf("LoadUncodedCodesFromReader: expect error\n")
+	}
+}
+
+// go test -v -run="TestDiffSynthetic$"
+func TestDiffSynthetic(t *testing.T) {
+	ctx := context.Background()
+	oldTable, err := LoadDistrictFromReaderWithOptions(ctx, strings.NewReader("460000,海南省\n460300,三沙市\n,西沙区"), LoadOptions{SyntheticCodes: true})
+	if err != nil {
+		t.Fatalf("LoadDistrictFromReaderWithOptions error: %s\n", err.Error())
+	}
+	newTable, _ := LoadDistrictFromReader(ctx, strings.NewReader("460000,海南省\n460300,三沙市\n460399,永兴市"))
+
+	// 以后正式分配的 460399 不是合成代码 460399 的更名
+	changeSet := Diff(oldTable, newTable)
+	if len(changeSet.Changes) != 1 || changeSet.Changes[0].Kind != ChangeAdded || changeSet.Changes[0].Code != 460399 {
+		t.Errorf("Diff:\n%s", changeSet.String())
+	}
+
+	sql := MigrationSql(oldTable, newTable, "t_dict_district")
+	if !strings.HasPrefix(sql, "-- DELETE: 1, UPDATE: 0, INSERT: 1\n") ||
+		!strings.Contains(sql, "DELETE FROM t_dict_district WHERE f_province_code=460000 AND f_city_code=460300 AND f_county_code=460399;\n") {
+		t.Errorf("MigrationSql:\n%s", sql)
+	}
+}
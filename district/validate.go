@@ -0,0 +1,274 @@
+// Package district
+package district
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IssueKind 数据问题类型
+type IssueKind string
+
+const (
+	IssueInvalidFormat   IssueKind = "invalid_format"   // 行格式不是“行政区代码,行政区名称”
+	IssueInvalidCode     IssueKind = "invalid_code"     // 行政区代码不是合法的 6 位数字
+	IssueUnknownProvince IssueKind = "unknown_province" // 行政区代码的前两位不是已知的省级行政区
+	IssueDuplicateCode   IssueKind = "duplicate_code"   // 行政区代码重复
+	IssueDuplicateName   IssueKind = "duplicate_name"   // 同一上级行政区下行政区名重复
+	IssueMissingParent   IssueKind = "missing_parent"   // 上级行政区不存在
+	IssueSkippedRow      IssueKind = "skipped_row"      // 被跳过的行（如标题行）
+	IssueUncoded         IssueKind = "uncoded"          // 无编码的行（如：,西沙区），需通过补充映射文件或合成代码加载
+)
+
+// Severity 问题的严重程度
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // 错误，数据不可用
+	SeverityWarning Severity = "warning" // 警告，数据可用但需留意
+)
+
+// Issue 数据源文件中的一个问题
+type Issue struct {
+	LineNo   int       `json:"line_no"`
+	Line     string    `json:"line"`
+	Code     uint32    `json:"code,omitempty"`
+	Kind     IssueKind `json:"kind"`
+	Severity Severity  `json:"severity"`
+	Message  string    `json:"message"`
+}
+
+// ValidationReport 数据源文件的校验报告
+type ValidationReport struct {
+	Lines     int     `json:"lines"`     // 非空行数
+	Districts int     `json:"districts"` // 有效的行政区数
+	Uncoded   int     `json:"uncoded"`   // 无编码的行数
+	Errors    int     `json:"errors"`
+	Warnings  int     `json:"warnings"`
+	Issues    []Issue `json:"issues"`
+}
+
+// provincePrefixes 已知的省级行政区代码前两位（GB/T 2260）
+var provincePrefixes = map[uint32]bool{
+	11: true, 12: true, 13: true, 14: true, 15: true,
+	21: true, 22: true, 23: true,
+	31: true, 32: true, 33: true, 34: true, 35: true, 36: true, 37: true,
+	41: true, 42: true, 43: true, 44: true, 45: true, 46: true,
+	50: true, 51: true, 52: true, 53: true, 54: true,
+	61: true, 62: true, 63: true, 64: true, 65: true,
+	71: true, 81: true, 82: true,
+}
+
+// HasError 是否有错误级别的问题
+func (r *ValidationReport) HasError() bool {
+	return r.Errors > 0
+}
+
+// String 以文本方式输出报告，每个问题一行，最后一行为汇总
+func (r *ValidationReport) String() string {
+	var builder strings.Builder
+	for _, issue := range r.Issues {
+		builder.WriteString(fmt.Sprintf("line %d: [%s] %s: %s (%s)\n",
+			issue.LineNo, issue.Severity, issue.Kind, issue.Message, issue.Line))
+	}
+	builder.WriteString(fmt.Sprintf("lines: %d, districts: %d, uncoded: %d, errors: %d, warnings: %d\n",
+		r.Lines, r.Districts, r.Uncoded, r.Errors, r.Warnings))
+	return builder.String()
+}
+
+func (r *ValidationReport) addIssue(lineNo int, line string, code uint32, kind IssueKind, severity Severity, message string) {
+	r.Issues = append(r.Issues, Issue{
+		LineNo:   lineNo,
+		Line:     line,
+		Code:     code,
+		Kind:     kind,
+		Severity: severity,
+		Message:  message,
+	})
+	if severity == SeverityError {
+		r.Errors++
+	} else {
+		r.Warnings++
+	}
+}
+
+// ValidateDistrict 校验数据源文件，不同于 LoadDistrict 遇错即止，会扫描整个文件并报告所有问题
+func ValidateDistrict(ctx context.Context, filepath string) (*ValidationReport, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ValidateDistrictFromReader(ctx, file)
+}
+
+// ValidateDistrictFromReader 从 io.Reader 校验数据源，也支持国家统计局的统计用区划代码（含乡级和村级），
+// 返回的 error 只表示读取失败，数据问题记录在报告中
+func ValidateDistrictFromReader(ctx context.Context, r io.Reader) (*ValidationReport, error) {
+	type entry struct {
+		lineNo int
+		line   string
+		name   string
+	}
+
+	report := &ValidationReport{Issues: make([]Issue, 0)}
+	reader, err := newBufferedReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// 第一遍：逐行检查格式和代码，同时收集有效的行政区
+	lineNo := 0
+	codeTable := make(map[uint32]entry)
+	codes := make([]uint32, 0)
+	lastCode := uint32(0) // 上一个有效的行政区代码，用于确定无编码行的上级
+	// 统计用区划代码的省市县，用于检查乡级和村级的上级
+	statisticalTable := Table{ProvinceDistrictTable: make(map[uint32]ProvinceDistrict)}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		lineNo = lineNo + 1
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			if len(line) == 0 {
+				break
+			}
+		}
+
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		report.Lines++
+
+		var code uint64
+		var name string
+		var statistical *District // 统计用区划代码的省市县
+		if isStatisticalLine(line) {
+			// 国家统计局的统计用区划代码，乡级和村级只检查上级是否存在
+			if n := len(strings.Split(line, ",")); n != 2 && n != 3 {
+				report.addIssue(lineNo, line, 0, IssueInvalidFormat, SeverityError,
+					"expected format: StatisticalCode[,UrbanRuralCode],Name")
+				continue
+			}
+			statistical, err = parseStatisticalLine(&statisticalTable, lineNo, line)
+			if err != nil {
+				report.addIssue(lineNo, line, lineDistrictCode(line), IssueMissingParent, SeverityError,
+					"parent district or township not found")
+				continue
+			}
+			if statistical == nil {
+				// 乡级、村级或统计用的汇总行
+				continue
+			}
+			code, name = uint64(statistical.Code), statistical.Name
+		} else {
+			parts := strings.Split(line, ",")
+			if len(parts) != 2 {
+				report.addIssue(lineNo, line, 0, IssueInvalidFormat, SeverityError,
+					"expected format: DistrictCode,DistrictName")
+				continue
+			}
+			codeStr := strings.TrimSpace(parts[0])
+			name = strings.TrimSpace(parts[1])
+			if len(codeStr) == 0 {
+				report.Uncoded++
+				report.addIssue(lineNo, line, 0, IssueUncoded, SeverityWarning,
+					fmt.Sprintf("empty district code of %s after %d, supply a code by mapping file or use synthetic codes", name, lastCode))
+				continue
+			}
+			code, err = strconv.ParseUint(codeStr, 10, 32)
+			if err != nil {
+				if lineNo == 1 {
+					report.addIssue(lineNo, line, 0, IssueSkippedRow, SeverityWarning, "header row")
+				} else {
+					report.addIssue(lineNo, line, 0, IssueInvalidCode, SeverityError,
+						fmt.Sprintf("invalid district code %s", codeStr))
+				}
+				continue
+			}
+		}
+		if code < 100000 || code > 999999 {
+			report.addIssue(lineNo, line, uint32(code), IssueInvalidCode, SeverityError,
+				"district code must be 6 digits")
+			continue
+		}
+		if !provincePrefixes[uint32(code/10000)] {
+			report.addIssue(lineNo, line, uint32(code), IssueUnknownProvince, SeverityError,
+				fmt.Sprintf("unknown province prefix %d", code/10000))
+			continue
+		}
+		if len(name) == 0 {
+			report.addIssue(lineNo, line, uint32(code), IssueInvalidFormat, SeverityError, "empty district name")
+			continue
+		}
+		if first, ok := codeTable[uint32(code)]; ok {
+			report.addIssue(lineNo, line, uint32(code), IssueDuplicateCode, SeverityError,
+				fmt.Sprintf("duplicated with line %d", first.lineNo))
+			continue
+		}
+
+		codeTable[uint32(code)] = entry{lineNo: lineNo, line: line, name: name}
+		if _, ok := statisticalTable.ProvinceDistrictTable[getProvinceDistrictCode(uint32(code))]; statistical != nil && (ok || IsProvinceDistrictCode(uint32(code))) {
+			// 供乡级和村级查找上级，省不存在的由第二遍报告
+			_ = addDistrict(&statisticalTable, lineNo, line, statistical)
+		}
+		codes = append(codes, uint32(code))
+		lastCode = uint32(code)
+	}
+
+	// 第二遍：检查上级行政区和同级重名
+	nameTable := make(map[string]int)
+	for _, code := range codes {
+		e := codeTable[code]
+		provinceCode := getProvinceDistrictCode(code)
+		cityCode := getCityDistrictCode(code)
+		parent := provinceCode
+
+		if !IsProvinceDistrictCode(code) {
+			if _, ok := codeTable[provinceCode]; !ok {
+				report.addIssue(e.lineNo, e.line, code, IssueMissingParent, SeverityError,
+					fmt.Sprintf("province %d not found", provinceCode))
+				continue
+			}
+		}
+		if IsCountyDistrictCode(code) && !IsMunicipalityCode(code) {
+			if _, ok := codeTable[cityCode]; ok {
+				parent = cityCode
+			} else if (code/100)%100 != 90 {
+				// 第三、四位为 90 的是省直辖县级行政区，本就没有地级市
+				report.addIssue(e.lineNo, e.line, code, IssueMissingParent, SeverityWarning,
+					fmt.Sprintf("city %d not found, treated as county-level city", cityCode))
+			}
+		}
+		if IsProvinceDistrictCode(code) {
+			parent = 0
+		}
+
+		key := fmt.Sprintf("%d:%s", parent, e.name)
+		if firstLineNo, ok := nameTable[key]; ok {
+			report.addIssue(e.lineNo, e.line, code, IssueDuplicateName, SeverityWarning,
+				fmt.Sprintf("name duplicated with line %d under %d", firstLineNo, parent))
+			continue
+		}
+		nameTable[key] = e.lineNo
+		report.Districts++
+	}
+
+	// 按行号排序，同一行的保持原有顺序
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].LineNo < report.Issues[j].LineNo
+	})
+	return report, nil
+}
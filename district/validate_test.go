@@ -0,0 +1,89 @@
+// Package district
+package district
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// go test -v -run="TestValidateDistrict$"
+func TestValidateDistrict(t *testing.T) {
+	data := `行政区划代码,单位名称
+440000,广东省
+440400,珠海市
+440402,香洲区
+440402,香洲区
+440403,香洲区
+440501,金平区
+,南沙区
+990000,未知省
+12345,短代码
+abc,非法代码
+450101
+330101,西湖区`
+
+	report, err := ValidateDistrictFromReader(context.Background(), strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ValidateDistrictFromReader error: %s\n", err.Error())
+	}
+	t.Logf("\n%s", report.String())
+
+	expects := []struct {
+		lineNo int
+		kind   IssueKind
+	}{
+		{1, IssueSkippedRow},
+		{5, IssueDuplicateCode},
+		{6, IssueDuplicateName},
+		{7, IssueMissingParent},
+		{8, IssueUncoded},
+		{9, IssueUnknownProvince},
+		{10, IssueInvalidCode},
+		{11, IssueInvalidCode},
+		{12, IssueInvalidFormat},
+		{13, IssueMissingParent},
+	}
+	if len(report.Issues) != len(expects) {
+		t.Fatalf("issues: %d, expect %d\n", len(report.Issues), len(expects))
+	}
+	for i, expect := range expects {
+		issue := report.Issues[i]
+		if issue.LineNo != expect.lineNo || issue.Kind != expect.kind {
+			t.Errorf("issue %d: line %d %s, expect line %d %s\n", i, issue.LineNo, issue.Kind, expect.lineNo, expect.kind)
+		}
+	}
+	if !report.HasError() || report.Districts != 4 || report.Uncoded != 1 {
+		t.Errorf("errors: %d, districts: %d, uncoded: %d\n", report.Errors, report.Districts, report.Uncoded)
+	}
+
+	report, err = ValidateDistrict(context.Background(), "../district-2023.csv")
+	if err != nil {
+		t.Fatalf("ValidateDistrict error: %s\n", err.Error())
+	}
+	if report.HasError() {
+		t.Errorf("district-2023.csv:\n%s", report.String())
+	}
+}
+
+// go test -v -run="TestValidateStatistical$"
+func TestValidateStatistical(t *testing.T) {
+	report, err := ValidateDistrictFromReader(context.Background(), strings.NewReader(testStatisticalData))
+	if err != nil {
+		t.Fatalf("ValidateDistrictFromReader error: %s\n", err.Error())
+	}
+	t.Logf("\n%s", report.String())
+	if report.HasError() || len(report.Issues) != 1 || report.Issues[0].Kind != IssueSkippedRow || report.Districts != 8 {
+		t.Fatalf("errors: %d, issues: %v, districts: %d\n", report.Errors, report.Issues, report.Districts)
+	}
+
+	// 村级的上级乡级不存在
+	data := testStatisticalData + "\n440402003001,111,前山社区居委会"
+	report, err = ValidateDistrictFromReader(context.Background(), strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ValidateDistrictFromReader error: %s\n", err.Error())
+	}
+	if !report.HasError() || report.Issues[len(report.Issues)-1].Kind != IssueMissingParent || report.Issues[len(report.Issues)-1].Code != 440402 {
+		t.Errorf("issues: %v\n", report.Issues)
+	}
+}
@@ -0,0 +1,107 @@
+// Package district
+package district
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Version 一个版本（年度）的行政区数据
+type Version struct {
+	Name          string    // 版本名，如：2023
+	EffectiveDate time.Time // 生效日期
+	Table         *Table
+	query         *MemoryQuery
+}
+
+// VersionedStore 多版本的行政区数据，按生效日期解释历史数据，
+// 如历史订单和身份证登记时的行政区划
+type VersionedStore struct {
+	versions []*Version // 按生效日期从早到晚排序
+}
+
+// NewVersionedStore 新建空的多版本行政区数据
+func NewVersionedStore() *VersionedStore {
+	return &VersionedStore{
+		versions: make([]*Version, 0),
+	}
+}
+
+// Add 添加一个版本，生效日期相同的会替换原有版本
+func (s *VersionedStore) Add(name string, effectiveDate time.Time, table *Table) {
+	version := &Version{
+		Name:          name,
+		EffectiveDate: effectiveDate,
+		Table:         table,
+		query:         NewMemoryQuery(table),
+	}
+
+	for i, v := range s.versions {
+		if v.EffectiveDate.Equal(effectiveDate) {
+			s.versions[i] = version
+			return
+		}
+	}
+	s.versions = append(s.versions, version)
+	sort.Slice(s.versions, func(i, j int) bool {
+		return s.versions[i].EffectiveDate.Before(s.versions[j].EffectiveDate)
+	})
+}
+
+// AddFile 从数据源文件添加一个版本
+func (s *VersionedStore) AddFile(ctx context.Context, name string, effectiveDate time.Time, filepath string) error {
+	table, err := LoadDistrict(ctx, filepath)
+	if err != nil {
+		return fmt.Errorf("load %s error: %s", filepath, err.Error())
+	}
+
+	s.Add(name, effectiveDate, table)
+	return nil
+}
+
+// Versions 取得所有版本，按生效日期从早到晚排序
+func (s *VersionedStore) Versions() []*Version {
+	versions := make([]*Version, len(s.versions))
+	copy(versions, s.versions)
+	return versions
+}
+
+// At 取得指定日期有效的版本，即生效日期不晚于 date 的最新版本，早于所有版本时返回 nil
+func (s *VersionedStore) At(date time.Time) *Version {
+	i := sort.Search(len(s.versions), func(i int) bool {
+		return s.versions[i].EffectiveDate.After(date)
+	})
+	if i == 0 {
+		return nil
+	}
+	return s.versions[i-1]
+}
+
+// NameAt 取得行政区代码在指定日期对应的行政区名
+// 返回值：
+// 1）成功返回非 nil 的 Name，同时 error 值为 nil ；
+// 2）不存在返回 nil 的 Name，同时 error 值为 nil ；
+// 3）指定日期没有有效的版本时返回 nil 的 Name，同时 error 值不为 nil 。
+func (s *VersionedStore) NameAt(ctx context.Context, code *Code, date time.Time) (*Name, error) {
+	version := s.At(date)
+	if version == nil {
+		return nil, fmt.Errorf("no district data effective at %s", date.Format("2006-01-02"))
+	}
+	return version.query.GetDistrictName(ctx, code)
+}
+
+// CodeAt 取得行政区名在指定日期对应的行政区代码，返回值同 NameAt
+func (s *VersionedStore) CodeAt(ctx context.Context, name *Name, date time.Time) (*Code, error) {
+	version := s.At(date)
+	if version == nil {
+		return nil, fmt.Errorf("no district data effective at %s", date.Format("2006-01-02"))
+	}
+	return version.query.GetDistrictCode(ctx, name)
+}
+
+// Query 取得版本的查询器
+func (v *Version) Query() *MemoryQuery {
+	return v.query
+}
@@ -0,0 +1,45 @@
+// Package district
+package district
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// go test -v -run="TestVersionedStore$"
+func TestVersionedStore(t *testing.T) {
+	ctx := context.Background()
+	oldTable, _ := LoadDistrictFromReader(ctx, strings.NewReader(testOldDistrictData))
+	newTable, _ := LoadDistrictFromReader(ctx, strings.NewReader(testNewDistrictData))
+
+	store := NewVersionedStore()
+	store.Add("2023", time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local), newTable)
+	store.Add("2022", time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local), oldTable)
+
+	code := &Code{ProvinceCode: 540000, CityCode: 540400, CountyCode: 540422}
+	name, err := store.NameAt(ctx, code, time.Date(2022, 6, 1, 0, 0, 0, 0, time.Local))
+	if err != nil || name == nil || name.CountyName != "米林县" {
+		t.Errorf("NameAt(540422, 2022-06-01): %v %v\n", name, err)
+	}
+	name, err = store.NameAt(ctx, code, time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local))
+	if err != nil || name != nil {
+		t.Errorf("NameAt(540422, 2023-06-01): %v %v\n", name, err)
+	}
+	_, err = store.NameAt(ctx, code, time.Date(2021, 6, 1, 0, 0, 0, 0, time.Local))
+	if err == nil {
+		t.Errorf("NameAt(540422, 2021-06-01): expect error\n")
+	}
+
+	result, err := store.CodeAt(ctx, &Name{ProvinceName: "西藏自治区", CityName: "林芝市", CountyName: "米林市"},
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local))
+	if err != nil || result == nil || result.CountyCode != 540481 {
+		t.Errorf("CodeAt(米林市, 2023-01-01): %v %v\n", result, err)
+	}
+
+	versions := store.Versions()
+	if len(versions) != 2 || versions[0].Name != "2022" || versions[1].Name != "2023" {
+		t.Errorf("Versions: %v\n", versions)
+	}
+}
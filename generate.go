@@ -0,0 +1,345 @@
+package main
+
+import (
+    "context"
+    "flag"
+    "fmt"
+    "github.com/eyjian/mooon-district/district"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// stdout 作为输出文件时表示标准输出
+const stdout = "-"
+
+// generateFlags generate 命令的参数
+type generateFlags struct {
+    data *dataFlags
+
+    formats        *string
+    withJson       *bool
+    withJsonIndent *bool
+    jsonIndent     *string
+    jsonPrefix     *string
+    jsonStyle      *string
+    cascaderKeys   *string
+    cascaderString *bool
+    cascaderLevels *int
+
+    withCsv      *bool
+    csvDelimiter *string
+    csvWithCode  *bool
+
+    withSql       *bool
+    withSqlIgnore *bool
+    sqlTable      *string
+
+    withXlsx *bool
+
+    output  *string
+    outDir  *string
+    jsonOut *string
+    csvOut  *string
+    sqlOut  *string
+    xlsxOut *string
+    force   *bool
+
+    withPinyin *bool
+    withPostal *bool
+}
+
+// newGenerateFlags 在参数集中注册 generate 命令的参数
+func newGenerateFlags(flagSet *flag.FlagSet) *generateFlags {
+    return &generateFlags{
+        data: newDataFlags(flagSet),
+
+        formats:        flagSet.String("format", "", "Comma separated formats to generate: json, csv, sql and xlsx (e.g., -format=json,sql), the same as -with-json and so on."),
+        withJson:       flagSet.Bool("with-json", false, "Whether to generate json format data."),
+        withJsonIndent: flagSet.Bool("with-json-indent", true, "Whether JSON format is indented."),
+        jsonIndent:     flagSet.String("json-indent", "  ", "Json indent when -with-json-indent is enabled."),
+        jsonPrefix:     flagSet.String("json-prefix", "", "Prefix for each line when -with-json-indent is enabled."),
+        jsonStyle:      flagSet.String("json-style", "tree", "Style of json format data: tree (provinces, cities and counties), cascader (for Element, Ant Design and so on) or vant (for the Area of Vant)."),
+        cascaderKeys:   flagSet.String("cascader-keys", "value,label,children", "Key names of value, label and children when -json-style=cascader (e.g., -cascader-keys=code,name,areaList)."),
+        cascaderString: flagSet.Bool("cascader-string-code", false, "Whether the value is a string code when -json-style=cascader."),
+        cascaderLevels: flagSet.Int("cascader-levels", 3, "Levels when -json-style=cascader: 2 (provinces and cities) or 3."),
+
+        withCsv:      flagSet.Bool("with-csv", false, "Whether to generate csv format data."),
+        csvDelimiter: flagSet.String("csv-delimiter", ",", "Delimiter of csv data."),
+        csvWithCode:  flagSet.Bool("csv-with-code", true, "Whether the csv format outputs the code column."),
+
+        withSql:       flagSet.Bool("with-sql", false, "Whether to generate sql data."),
+        withSqlIgnore: flagSet.Bool("with-sql-ignore", false, "Use `INSERT IGNORE` to ignore existing."),
+        sqlTable:      flagSet.String("sql-table", "t_dict_district", "Table name for sql data."),
+
+        withXlsx: flagSet.Bool("with-xlsx", false, "Whether to generate xlsx data."),
+
+        output:  flagSet.String("o", "", "Output file when only one format is generated, '-' means stdout (not for xlsx)."),
+        outDir:  flagSet.String("out-dir", ".", "Directory of the default output files example.json, example.csv, example.sql and example.xlsx."),
+        jsonOut: flagSet.String("json-out", "", "Output file of json format data, '-' means stdout (default: example.json in -out-dir)."),
+        csvOut:  flagSet.String("csv-out", "", "Output file of csv format data, '-' means stdout (default: example.csv in -out-dir)."),
+        sqlOut:  flagSet.String("sql-out", "", "Output file of sql data, '-' means stdout (default: example.sql in -out-dir)."),
+        xlsxOut: flagSet.String("xlsx-out", "", "Output file of xlsx data (default: example.xlsx in -out-dir)."),
+        force:   flagSet.Bool("force", false, "Whether to overwrite existing output files."),
+
+        withPinyin: flagSet.Bool("with-pinyin", false, "Whether csv, sql and xlsx output pinyin, pinyin initials and english name."),
+        withPostal: flagSet.Bool("with-postal", false, "Whether csv and sql output area code and postal code."),
+    }
+}
+
+// runGenerate 生成 json、csv、sql 和 xlsx 格式的数据
+// 用法：mooon-district generate -f district-2023.csv -format=json,sql
+func runGenerate(args []string) int {
+    flagSet := newFlagSet("generate", "generate [-f district-2023.csv] -format=json,csv,sql,xlsx [flags]")
+    flags := newGenerateFlags(flagSet)
+    _ = flagSet.Parse(args)
+
+    enabled, err := flags.enabled()
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "%s.\n", err.Error())
+        return 1
+    }
+    if !enabled {
+        fmt.Fprintf(os.Stderr, "Parameter -format is not set.\n")
+        flagSet.Usage()
+        return 1
+    }
+    return flags.generate()
+}
+
+// runLegacyGenerate 兼容不带子命令的用法，没有指定要生成的格式时退出码为 4
+// 用法：mooon-district -f district-2023.csv -with-json=true
+func runLegacyGenerate(args []string) int {
+    flagSet := newFlagSet("", "-f district-2023.csv -with-json=true [flags]")
+    version := flagSet.Bool("v", false, "Display version info and exit.")
+    flags := newGenerateFlags(flagSet)
+    _ = flagSet.Parse(args)
+    // 不带子命令的用法同以前的版本一样，直接覆盖已存在的输出文件
+    *flags.force = true
+
+    if *version {
+        showVersion()
+        return 1
+    }
+    enabled, err := flags.enabled()
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "%s.\n", err.Error())
+        return 1
+    }
+    if !enabled {
+        fmt.Fprintf(os.Stderr, "Do nothing.\n")
+        return 4
+    }
+    return flags.generate()
+}
+
+// enabled 是否指定了要生成的格式，-format 指定的格式合并到 -with-json 等参数
+func (g *generateFlags) enabled() (bool, error) {
+    for _, format := range strings.Split(*g.formats, ",") {
+        switch format = strings.TrimSpace(format); format {
+        case "":
+        case "json":
+            *g.withJson = true
+        case "csv":
+            *g.withCsv = true
+        case "sql":
+            *g.withSql = true
+        case "xlsx":
+            *g.withXlsx = true
+        default:
+            return false, fmt.Errorf("Parameter -format is invalid: %s", format)
+        }
+    }
+    return *g.withJson || *g.withCsv || *g.withSql || *g.withXlsx, nil
+}
+
+func (g *generateFlags) generate() int {
+    if !g.checkParameters() {
+        return 1
+    }
+
+    districtTable, err := g.data.load(context.Background())
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "%s.\n", capitalize(err.Error()))
+        return 2
+    }
+
+    if *g.withJson {
+        path := g.outputPath(*g.jsonOut, "example.json")
+        err := g.prepareOutput(path)
+        if err == nil {
+            err = g.generateJson(districtTable, path)
+        }
+        if err != nil {
+            fmt.Fprintf(os.Stderr, "Generate json error: %s.\n", err.Error())
+            return 3
+        }
+    }
+    if *g.withCsv {
+        path := g.outputPath(*g.csvOut, "example.csv")
+        options := district.CsvOptions{
+            Delimiter:  *g.csvDelimiter,
+            WithCode:   *g.csvWithCode,
+            WithPinyin: *g.withPinyin,
+            WithPostal: *g.withPostal,
+        }
+        err := g.prepareOutput(path)
+        if err == nil {
+            if path == stdout {
+                err = district.WriteCsv(districtTable, os.Stdout, options)
+            } else {
+                err = district.GenerateCsvWithOptions(districtTable, path, options)
+            }
+        }
+        if err != nil {
+            fmt.Fprintf(os.Stderr, "Generate csv error: %s.\n", err.Error())
+            return 3
+        }
+    }
+    if *g.withSql {
+        path := g.outputPath(*g.sqlOut, "example.sql")
+        options := district.SqlOptions{
+            TableName:  *g.sqlTable,
+            WithIgnore: *g.withSqlIgnore,
+            WithPinyin: *g.withPinyin,
+            WithPostal: *g.withPostal,
+        }
+        err := g.prepareOutput(path)
+        if err == nil {
+            if path == stdout {
+                err = district.WriteSql(districtTable, os.Stdout, options)
+            } else {
+                err = district.GenerateSqlWithOptions(districtTable, path, options)
+            }
+        }
+        if err != nil {
+            fmt.Fprintf(os.Stderr, "Generate sql error: %s.\n", err.Error())
+            return 3
+        }
+    }
+    if *g.withXlsx {
+        path := g.outputPath(*g.xlsxOut, "example.xlsx")
+        err := g.prepareOutput(path)
+        if err == nil {
+            err = district.GenerateXlsxWithOptions(districtTable, path, district.XlsxOptions{WithPinyin: *g.withPinyin})
+        }
+        if err != nil {
+            fmt.Fprintf(os.Stderr, "Generate xlsx error: %s.\n", err.Error())
+            return 3
+        }
+    }
+    return 0
+}
+
+// generateJson 按 -json-style 生成 json 格式数据，path 为 stdout 时写到标准输出
+func (g *generateFlags) generateJson(districtTable *district.Table, path string) error {
+    switch *g.jsonStyle {
+    case "cascader":
+        keys := strings.Split(*g.cascaderKeys, ",")
+        options := district.CascaderOptions{
+            ValueKey:     keys[0],
+            LabelKey:     keys[1],
+            ChildrenKey:  keys[2],
+            CodeAsString: *g.cascaderString,
+            Levels:       *g.cascaderLevels,
+        }
+        if path == stdout {
+            return district.WriteCascaderJson(districtTable, os.Stdout, options, *g.withJsonIndent, *g.jsonIndent, *g.jsonPrefix)
+        }
+        return district.GenerateCascaderJson(districtTable, path, options, *g.withJsonIndent, *g.jsonIndent, *g.jsonPrefix)
+    case "vant":
+        if path == stdout {
+            return district.WriteVantJson(districtTable, os.Stdout, *g.withJsonIndent, *g.jsonIndent, *g.jsonPrefix)
+        }
+        return district.GenerateVantJson(districtTable, path, *g.withJsonIndent, *g.jsonIndent, *g.jsonPrefix)
+    default:
+        if path == stdout {
+            return district.WriteJson(districtTable, os.Stdout, *g.withJsonIndent, *g.jsonIndent, *g.jsonPrefix)
+        }
+        return district.GenerateJson(districtTable, path, *g.withJsonIndent, *g.jsonIndent, *g.jsonPrefix)
+    }
+}
+
+// outputPath 取得格式的输出文件，依次为格式的输出参数（如 -json-out）、-o 和 -out-dir 下的缺省文件名
+func (g *generateFlags) outputPath(formatOutput, defaultName string) string {
+    if len(formatOutput) > 0 {
+        return formatOutput
+    }
+    if len(*g.output) > 0 {
+        return *g.output
+    }
+    return filepath.Join(*g.outDir, defaultName)
+}
+
+// prepareOutput 检查输出文件，不指定 -force 时拒绝覆盖已存在的文件，并创建不存在的目录
+func (g *generateFlags) prepareOutput(path string) error {
+    if path == stdout {
+        return nil
+    }
+
+    _, err := os.Stat(path)
+    if err == nil {
+        if !*g.force {
+            return fmt.Errorf("file://%s already exists, use -force to overwrite", path)
+        }
+        return nil
+    }
+    if !os.IsNotExist(err) {
+        return err
+    }
+    return os.MkdirAll(filepath.Dir(path), 0755)
+}
+
+func (g *generateFlags) checkParameters() bool {
+    if *g.withSql {
+        if len(*g.sqlTable) == 0 {
+            fmt.Fprintf(os.Stderr, "Parameter -sql-table is not set.\n")
+            return false
+        }
+    }
+
+    if *g.withJson {
+        if *g.jsonStyle != "tree" && *g.jsonStyle != "cascader" && *g.jsonStyle != "vant" {
+            fmt.Fprintf(os.Stderr, "Parameter -json-style is invalid: %s.\n", *g.jsonStyle)
+            return false
+        }
+        keys := strings.Split(*g.cascaderKeys, ",")
+        if len(keys) != 3 || len(keys[0]) == 0 || len(keys[1]) == 0 || len(keys[2]) == 0 {
+            fmt.Fprintf(os.Stderr, "Parameter -cascader-keys is invalid: %s.\n", *g.cascaderKeys)
+            return false
+        }
+        if *g.cascaderLevels != 2 && *g.cascaderLevels != 3 {
+            fmt.Fprintf(os.Stderr, "Parameter -cascader-levels is invalid: %d.\n", *g.cascaderLevels)
+            return false
+        }
+    }
+
+    // -o 只能用于一种格式，标准输出只能用于一种文本格式
+    formats, stdouts := 0, 0
+    for _, format := range []struct {
+        enabled bool
+        output  string
+    }{
+        {*g.withJson, *g.jsonOut}, {*g.withCsv, *g.csvOut}, {*g.withSql, *g.sqlOut}, {*g.withXlsx, *g.xlsxOut},
+    } {
+        if format.enabled {
+            formats++
+            if g.outputPath(format.output, "") == stdout {
+                stdouts++
+            }
+        }
+    }
+    if len(*g.output) > 0 && formats > 1 {
+        fmt.Fprintf(os.Stderr, "Parameter -o can only be used with one format, use -json-out, -csv-out, -sql-out or -xlsx-out instead.\n")
+        return false
+    }
+    if stdouts > 1 {
+        fmt.Fprintf(os.Stderr, "Only one format can be written to stdout.\n")
+        return false
+    }
+    if *g.withXlsx && g.outputPath(*g.xlsxOut, "") == stdout {
+        fmt.Fprintf(os.Stderr, "Xlsx can not be written to stdout.\n")
+        return false
+    }
+    return true
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+    "bufio"
+    "context"
+    "encoding/json"
+    "fmt"
+    "github.com/eyjian/mooon-district/district"
+    "github.com/eyjian/mooon-district/district/dataset"
+    "io"
+    "os"
+    "strings"
+)
+
+// lookupOutput lookup 命令一个输入的输出
+type lookupOutput struct {
+    Input string `json:"input"`
+    *district.LookupResult
+    Error string `json:"error,omitempty"` // 找不到或身份证号码不合法
+}
+
+// runLookup 通过行政区代码、斜杠分隔的行政区名或身份证号码查询行政区，
+// 不指定输入时从标准输入逐行读取，存在找不到的输入时退出码为 5
+// 用法：mooon-district lookup [-f district-2023.csv] [-format=json] 440402 河南省/济源市
+func runLookup(args []string) int {
+    flagSet := newFlagSet("lookup", "lookup [-f district-2023.csv] [-format=json] [code|name|id ...]")
+    data := newDataFlags(flagSet)
+    format := flagSet.String("format", "text", "Output format of the results: text or json (one object per line).")
+    _ = flagSet.Parse(args)
+
+    if *format != "text" && *format != "json" {
+        fmt.Fprintf(os.Stderr, "Parameter -format is invalid: %s.\n", *format)
+        return 1
+    }
+
+    districtTable, err := data.load(context.Background())
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "%s.\n", capitalize(err.Error()))
+        return 2
+    }
+    registry, err := dataset.Succession()
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Load succession error: %s.\n", err.Error())
+        return 2
+    }
+    lookup := district.NewLookup(districtTable, registry)
+
+    inputs := flagSet.Args()
+    if len(inputs) == 0 || (len(inputs) == 1 && inputs[0] == stdout) {
+        return lookupReader(lookup, os.Stdin, os.Stdout, *format)
+    }
+    return lookupReader(lookup, strings.NewReader(strings.Join(inputs, "\n")), os.Stdout, *format)
+}
+
+// lookupReader 逐行查询 r 中的输入，结果写到 w
+func lookupReader(lookup *district.Lookup, r io.Reader, w io.Writer, format string) int {
+    exitCode := 0
+    writer := bufio.NewWriter(w)
+    defer writer.Flush()
+
+    scanner := bufio.NewScanner(r)
+    for scanner.Scan() {
+        input := strings.TrimSpace(scanner.Text())
+        if len(input) == 0 {
+            continue
+        }
+
+        output := lookupOutput{Input: input}
+        result, err := lookup.Lookup(input)
+        if err != nil {
+            output.Error = err.Error()
+        } else if result == nil || result.Code == nil {
+            output.Error = "not found"
+        }
+        if result != nil {
+            output.LookupResult = result
+        }
+        if len(output.Error) > 0 {
+            exitCode = 5
+        }
+
+        if format == "json" {
+            jsonBytes, _ := json.Marshal(output)
+            writer.Write(jsonBytes)
+            writer.WriteString("\n")
+        } else {
+            writer.WriteString(output.String())
+        }
+        // 批量时逐行输出，便于管道中的下游及时处理
+        writer.Flush()
+    }
+    if err := scanner.Err(); err != nil {
+        fmt.Fprintf(os.Stderr, "Read input error: %s.\n", err.Error())
+        return 2
+    }
+    return exitCode
+}
+
+// String 文本格式的输出，以制表符分隔：输入、代码、完整路径、级别和标志，如：
+// 440402	440402	广东省/珠海市/香洲区	3	-
+func (o *lookupOutput) String() string {
+    if o.LookupResult == nil || o.Code == nil {
+        return fmt.Sprintf("%s\t%s\n", o.Input, o.Error)
+    }
+
+    flags := make([]string, 0)
+    if o.Municipality {
+        flags = append(flags, "municipality")
+    }
+    if o.CountyLevelCity {
+        flags = append(flags, "county_level_city")
+    }
+    if o.ProvinceDirect {
+        flags = append(flags, "province_direct")
+    }
+    if o.IdCard != nil {
+        flags = append(flags, fmt.Sprintf("birthday=%s", o.IdCard.Birthday.Format("2006-01-02")),
+            fmt.Sprintf("gender=%s", o.IdCard.Gender))
+        if o.IdCard.Historical {
+            flags = append(flags, "historical")
+        }
+        if o.IdCard.Inexact {
+            flags = append(flags, "inexact")
+        }
+    }
+    if len(flags) == 0 {
+        flags = append(flags, "-")
+    }
+    return fmt.Sprintf("%s\t%d\t%s\t%d\t%s\n", o.Input, o.Code.Lowest(), o.Path, o.Level, strings.Join(flags, ","))
+}
@@ -2,117 +2,194 @@
 package main
 
 import (
+    "bytes"
     "context"
     "flag"
     "fmt"
     "github.com/eyjian/mooon-district/district"
+    "github.com/eyjian/mooon-district/district/dataset"
     "os"
-)
-
-var (
-    help             = flag.Bool("h", false, "Display a help message and exit.")
-    version          = flag.Bool("v", false, "Display version info and exit.")
-    districtDataFile = flag.String("f", "", "Path to the district data file (e.g., -f=district-2022.csv).")
-
-    withJson       = flag.Bool("with-json", false, "Whether to generate json format data.")
-    withJsonIndent = flag.Bool("with-json-indent", true, "Whether JSON format is indented.")
-    jsonIndent     = flag.String("json-indent", "  ", "Json indent when -with-json-indent is enabled.")
-    jsonPrefix     = flag.String("json-prefix", "", "Prefix for each line when -with-json-indent is enabled.")
-
-    withCsv      = flag.Bool("with-csv", false, "Whether to generate csv format data.")
-    csvDelimiter = flag.String("csv-delimiter", ",", "Delimiter of csv data.")
-    csvWithCode  = flag.Bool("csv-with-code", true, "Whether the csv format outputs the code column.")
-
-    withSql       = flag.Bool("with-sql", false, "Whether to generate sql data.")
-    withSqlIgnore = flag.Bool("with-sql-ignore", false, "Use `INSERT IGNORE` to ignore existing.")
-    sqlTable      = flag.String("sql-table", "t_dict_district", "Table name for sql data.")
-
-    withXlsx = flag.Bool("with-xlsx", false, "Whether to generate xlsx data.")
+    "strings"
 )
 
 var (
     buildTime string // build time
 )
 
+// command 子命令
+type command struct {
+    name        string
+    description string
+    run         func(args []string) int
+}
+
+// commands 所有的子命令，用法：mooon-district <command> [flags]，各子命令的参数可通过 -h 查看
+var commands = []command{
+    {"generate", "Generate json, csv, sql or xlsx data from the district data file.", runGenerate},
+    {"lookup", "Look up districts by codes, slash separated names or id card numbers.", runLookup},
+    {"search", "Search districts by chinese, pinyin or pinyin initials.", runSearch},
+    {"validate", "Validate the district data file.", runValidate},
+    {"diff", "Compare two district data files.", runDiff},
+    {"convert", "Convert the district data file into another format, written to stdout by default.", runConvert},
+    {"serve", "Serve the district data over HTTP REST.", runServe},
+}
+
 func main() {
-    flag.Parse()
-    if *help {
+    if len(os.Args) < 2 {
         usage()
         os.Exit(1)
     }
-    if *version {
-        showVersion()
+
+    name := os.Args[1]
+    switch name {
+    case "-h", "-help", "--help":
+        usage()
         os.Exit(1)
-    }
-    if !checkParameters() {
+    case "-v", "-version", "--version":
+        showVersion()
         os.Exit(1)
+    case "help":
+        os.Exit(runHelp(os.Args[2:]))
     }
 
-    ctx := context.Background()
-    districtTable, err := district.LoadDistrict(ctx, *districtDataFile)
-    if err != nil {
-        fmt.Fprintf(os.Stderr, "Load district error: %s.\n", err.Error())
-        os.Exit(2)
+    // 兼容不带子命令的用法，如：mooon-district -f district-2023.csv -with-json=true
+    if strings.HasPrefix(name, "-") {
+        os.Exit(runLegacyGenerate(os.Args[1:]))
     }
 
-    done := false
-    if *withJson {
-        done = true
-        err := district.GenerateJson(districtTable, "example.json", *withJsonIndent, *jsonIndent, *jsonPrefix)
-        if err != nil {
-            fmt.Fprintf(os.Stderr, "Generate json error: %s.\n", err.Error())
-            os.Exit(3)
+    for _, cmd := range commands {
+        if cmd.name == name {
+            os.Exit(cmd.run(os.Args[2:]))
         }
     }
-    if *withCsv {
-        done = true
-        err := district.GenerateCsv(districtTable, "example.csv", *csvDelimiter, *csvWithCode)
-        if err != nil {
-            fmt.Fprintf(os.Stderr, "Generate csv error: %s.\n", err.Error())
-            os.Exit(3)
-        }
+    fmt.Fprintf(os.Stderr, "Unknown command: %s, run `mooon-district help` for usage.\n", name)
+    os.Exit(1)
+}
+
+func usage() {
+    fmt.Fprintf(os.Stderr, "Usage: mooon-district <command> [flags]\n\nCommands:\n")
+    for _, cmd := range commands {
+        fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.description)
     }
-    if *withSql {
-        done = true
-        err := district.GenerateSql(districtTable, "example.sql", *sqlTable, *withSqlIgnore)
-        if err != nil {
-            fmt.Fprintf(os.Stderr, "Generate sql error: %s.\n", err.Error())
-            os.Exit(3)
-        }
+    fmt.Fprintf(os.Stderr, "  %-10s %s\n", "help", "Display the help message of a command.")
+    fmt.Fprintf(os.Stderr, "\nRun `mooon-district <command> -h` for the flags of a command.\n")
+    fmt.Fprintf(os.Stderr, "The legacy usage without command is the same as generate, e.g., mooon-district -f district-2023.csv -with-json=true\n")
+}
+
+// runHelp 显示子命令的帮助信息
+// 用法：mooon-district help [command]
+func runHelp(args []string) int {
+    if len(args) == 0 {
+        usage()
+        return 0
     }
-    if *withXlsx {
-        done = true
-        err := district.GenerateXlsx(districtTable, "example.xlsx")
-        if err != nil {
-            fmt.Fprintf(os.Stderr, "Generate xlsx error: %s.\n", err.Error())
-            os.Exit(3)
+    for _, cmd := range commands {
+        if cmd.name == args[0] {
+            return cmd.run([]string{"-h"})
         }
     }
-    if !done {
-        fmt.Fprintf(os.Stderr, "Do nothing.\n")
-        os.Exit(4)
+    fmt.Fprintf(os.Stderr, "Unknown command: %s.\n", args[0])
+    return 1
+}
+
+func showVersion() {
+    fmt.Printf("Version: %s, build at %s\n", "v0.0.1", buildTime)
+}
+
+// newFlagSet 新建子命令的参数集，-h 时显示用法和参数
+func newFlagSet(name, usage string) *flag.FlagSet {
+    flagSet := flag.NewFlagSet(name, flag.ExitOnError)
+    flagSet.Usage = func() {
+        fmt.Fprintf(flagSet.Output(), "Usage: mooon-district %s\n\nFlags:\n", usage)
+        flagSet.PrintDefaults()
     }
+    return flagSet
 }
 
-func usage() {
-    flag.Usage()
+// dataFlags 加载行政区数据的公共参数，不指定 -f 时使用内嵌的数据
+type dataFlags struct {
+    dataFile           *string
+    year               *int
+    withFunctional     *bool
+    withSummary        *bool
+    uncodedCodes       *string
+    withSyntheticCodes *bool
+    englishNames       *string
+    postalCodes        *string
 }
 
-func showVersion() {
-    fmt.Printf("Version: %s, build at %s\n", "v0.0.1", buildTime)
+// newDataFlags 在参数集中注册加载行政区数据的公共参数
+func newDataFlags(flagSet *flag.FlagSet) *dataFlags {
+    return &dataFlags{
+        dataFile:           flagSet.String("f", "", "Path to the district data file (e.g., -f=district-2022.csv), the embedded data is used if not set."),
+        year:               flagSet.Int("year", dataset.LatestYear(), "Year of the embedded data when -f is not set."),
+        withFunctional:     flagSet.Bool("with-functional", true, "Whether to include functional zones (e.g., 130471 经开区, 133100 雄安新区), which are not official districts but in the data file, their kind is functional."),
+        withSummary:        flagSet.Bool("with-summary", true, "Whether to include districts under the summary code xx90xx (e.g., 419001 济源市)."),
+        uncodedCodes:       flagSet.String("uncoded-codes", "", "Path to the codes file of uncoded districts (e.g., -uncoded-codes=uncoded.csv), format: DistrictName,DistrictCode."),
+        withSyntheticCodes: flagSet.Bool("with-synthetic-codes", false, "Whether to assign non-official synthetic codes (xxxx99 downwards, kind synthetic) to uncoded districts not in -uncoded-codes."),
+        englishNames:       flagSet.String("english-names", "", "Path to the official english names file (e.g., -english-names=english.csv), format: DistrictCode,EnglishName."),
+        postalCodes:        flagSet.String("postal-codes", "", "Path to the area codes and postal codes file (e.g., -postal-codes=postal.csv), format: DistrictCode,AreaCode,PostalCode."),
+    }
 }
 
-func checkParameters() bool {
-    if len(*districtDataFile) == 0 {
-        fmt.Fprintf(os.Stderr, "Parameter -f is not set.\n")
-        return false
+// load 按参数加载行政区数据
+func (d *dataFlags) load(ctx context.Context) (*district.Table, error) {
+    options := district.LoadOptions{
+        ExcludeFunctional: !*d.withFunctional,
+        ExcludeSummary:    !*d.withSummary,
+        SyntheticCodes:    *d.withSyntheticCodes,
+    }
+    if len(*d.uncodedCodes) > 0 {
+        codes, err := district.LoadUncodedCodes(ctx, *d.uncodedCodes)
+        if err != nil {
+            return nil, fmt.Errorf("load uncoded codes error: %s", err.Error())
+        }
+        options.UncodedCodes = codes
     }
 
-    if *withSql {
-        if len(*sqlTable) == 0 {
-            fmt.Fprintf(os.Stderr, "Parameter -sql-table is not set.\n")
-            return false
+    var err error
+    var districtTable *district.Table
+    if len(*d.dataFile) > 0 {
+        districtTable, err = district.LoadDistrictWithOptions(ctx, *d.dataFile, options)
+    } else {
+        var data []byte
+        data, err = dataset.Bytes(*d.year)
+        if err == nil {
+            districtTable, err = district.LoadDistrictFromReaderWithOptions(ctx, bytes.NewReader(data), options)
         }
     }
-    return true
-}
\ No newline at end of file
+    if err != nil {
+        return nil, fmt.Errorf("load district error: %s", err.Error())
+    }
+
+    // 用内嵌的和 -english-names 指定的官方英文名覆盖缺省的罗马字拼写
+    names, err := dataset.EnglishNames()
+    if err != nil {
+        return nil, fmt.Errorf("load english names error: %s", err.Error())
+    }
+    districtTable.SetEnglishNames(names)
+    if len(*d.englishNames) > 0 {
+        names, err = district.LoadEnglishNames(ctx, *d.englishNames)
+        if err != nil {
+            return nil, fmt.Errorf("load english names error: %s", err.Error())
+        }
+        districtTable.SetEnglishNames(names)
+    }
+
+    if len(*d.postalCodes) > 0 {
+        postalInfos, err := district.LoadPostalInfo(ctx, *d.postalCodes)
+        if err != nil {
+            return nil, fmt.Errorf("load postal codes error: %s", err.Error())
+        }
+        districtTable.SetPostalInfo(postalInfos)
+    }
+    return districtTable, nil
+}
+
+// capitalize 将错误信息的首字母大写，用于输出到标准错误
+func capitalize(message string) string {
+    if len(message) == 0 {
+        return message
+    }
+    return strings.ToUpper(message[:1]) + message[1:]
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "github.com/eyjian/mooon-district/district"
+    "os"
+    "strings"
+)
+
+// runSearch 按中文、全拼或拼音首字母搜索行政区
+// 用法：mooon-district search [-f district-2023.csv] [-limit=10] [-format=json] zhuhai
+func runSearch(args []string) int {
+    flagSet := newFlagSet("search", "search [-f district-2023.csv] [-limit=10] [-format=json] keyword")
+    data := newDataFlags(flagSet)
+    limit := flagSet.Int("limit", 10, "Maximum number of results, 0 means no limit.")
+    format := flagSet.String("format", "text", "Output format of the results: text or json.")
+    _ = flagSet.Parse(args)
+
+    keyword := strings.Join(flagSet.Args(), " ")
+    if len(keyword) == 0 {
+        fmt.Fprintf(os.Stderr, "Keyword is not set.\n")
+        flagSet.Usage()
+        return 1
+    }
+    if *format != "text" && *format != "json" {
+        fmt.Fprintf(os.Stderr, "Parameter -format is invalid: %s.\n", *format)
+        return 1
+    }
+
+    districtTable, err := data.load(context.Background())
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "%s.\n", capitalize(err.Error()))
+        return 2
+    }
+    results := district.NewSearcher(districtTable).Search(keyword, *limit)
+
+    if *format == "json" {
+        jsonBytes, err := json.MarshalIndent(results, "", "  ")
+        if err != nil {
+            fmt.Fprintf(os.Stderr, "Json marshal error: %s.\n", err.Error())
+            return 2
+        }
+        fmt.Println(string(jsonBytes))
+    } else {
+        for _, result := range results {
+            fmt.Printf("%d\t%s\t%.2f\n", result.Code.Lowest(), result.Path, result.Score)
+        }
+    }
+    return 0
+}
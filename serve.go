@@ -0,0 +1,45 @@
+package main
+
+import (
+    "context"
+    "fmt"
+    "github.com/eyjian/mooon-district/district/dataset"
+    "github.com/eyjian/mooon-district/district/httpapi"
+    "net/http"
+    "os"
+)
+
+// runServe 以 HTTP REST 接口提供行政区数据，接口见 httpapi.Handler
+// 用法：mooon-district serve [-f district-2023.csv] [-addr=:8080]
+func runServe(args []string) int {
+    flagSet := newFlagSet("serve", "serve [-f district-2023.csv] [-addr=:8080] [-max-age=86400]")
+    data := newDataFlags(flagSet)
+    addr := flagSet.String("addr", ":8080", "Address to listen on.")
+    maxAge := flagSet.Int("max-age", 86400, "Max age in seconds of Cache-Control, 0 means clients revalidate with ETag every time.")
+    version := flagSet.String("data-version", "", "Data version used as ETag, computed from the data if not set.")
+    _ = flagSet.Parse(args)
+
+    districtTable, err := data.load(context.Background())
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "%s.\n", capitalize(err.Error()))
+        return 2
+    }
+    registry, err := dataset.Succession()
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Load succession error: %s.\n", err.Error())
+        return 2
+    }
+
+    handler := httpapi.NewHandler(districtTable, httpapi.Options{
+        Version:  *version,
+        MaxAge:   *maxAge,
+        Registry: registry,
+    })
+    fmt.Fprintf(os.Stderr, "Listening on %s.\n", *addr)
+    err = http.ListenAndServe(*addr, handler)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Serve error: %s.\n", err.Error())
+        return 3
+    }
+    return 0
+}
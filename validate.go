@@ -0,0 +1,49 @@
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "github.com/eyjian/mooon-district/district"
+    "os"
+)
+
+// runValidate 校验数据源文件，有错误级别的问题时返回非 0 的退出码
+// 用法：mooon-district validate -f district-2023.csv [-format=json]
+func runValidate(args []string) int {
+    flagSet := newFlagSet("validate", "validate -f district-2023.csv [-format=json]")
+    dataFile := flagSet.String("f", "", "Path to the district data file to validate.")
+    format := flagSet.String("format", "text", "Output format of the report: text or json.")
+    _ = flagSet.Parse(args)
+
+    if len(*dataFile) == 0 {
+        fmt.Fprintf(os.Stderr, "Parameter -f is not set.\n")
+        return 1
+    }
+    if *format != "text" && *format != "json" {
+        fmt.Fprintf(os.Stderr, "Parameter -format is invalid: %s.\n", *format)
+        return 1
+    }
+
+    report, err := district.ValidateDistrict(context.Background(), *dataFile)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Validate district error: %s.\n", err.Error())
+        return 2
+    }
+
+    if *format == "json" {
+        jsonBytes, err := json.MarshalIndent(report, "", "  ")
+        if err != nil {
+            fmt.Fprintf(os.Stderr, "Json marshal error: %s.\n", err.Error())
+            return 2
+        }
+        fmt.Println(string(jsonBytes))
+    } else {
+        fmt.Print(report.String())
+    }
+
+    if report.HasError() {
+        return 5
+    }
+    return 0
+}